@@ -0,0 +1,159 @@
+package aqi
+
+import (
+	"errors"
+	"sort"
+)
+
+const (
+	MepGradeI  = 1
+	MepGradeII = 2
+
+	// days of valid daily values required for an annual assessment(HJ 663)
+	MepAnnualMinDays = 324
+)
+
+// MepLimit is a GB3095-2012 concentration limit, µg/m³ except co(mg/m³)
+type MepLimit struct {
+	GradeI, GradeII float64
+}
+
+// MepAttainment is the annual assessment of a pollutant of a site-year, the
+// pollutant is the tag of its daily values(o3_8h is the daily max 8-hr)
+type MepAttainment struct {
+	Pollutant       string
+	Days            int
+	Valid           bool // Days >= MepAnnualMinDays
+	AnnualMean      float64
+	AnnualLimit     float64 // 0 when the pollutant has no annual limit
+	Percentile      float64
+	PercentileValue float64
+	DailyLimit      float64
+	ExceedanceDays  int
+	Attained        bool
+}
+
+var (
+	mepLimits      map[string]MepLimit
+	mepPercentiles map[string]float64
+)
+
+// initliaze GB3095-2012 limits and HJ 663-2013 percentiles
+func init() {
+	mepLimits = map[string]MepLimit{
+		"so2_annual":  MepLimit{20, 60},
+		"so2_24h":     MepLimit{50, 150},
+		"so2_1h":      MepLimit{150, 500},
+		"no2_annual":  MepLimit{40, 40},
+		"no2_24h":     MepLimit{80, 80},
+		"no2_1h":      MepLimit{200, 200},
+		"co_24h":      MepLimit{4, 4},
+		"co_1h":       MepLimit{10, 10},
+		"o3_8h":       MepLimit{100, 160},
+		"o3_1h":       MepLimit{160, 200},
+		"pm10_annual": MepLimit{40, 70},
+		"pm10_24h":    MepLimit{50, 150},
+		"pm25_annual": MepLimit{15, 35},
+		"pm25_24h":    MepLimit{35, 75},
+	}
+
+	mepPercentiles = map[string]float64{
+		"so2_24h":  98,
+		"no2_24h":  98,
+		"co_24h":   95,
+		"o3_8h":    90,
+		"pm10_24h": 95,
+		"pm25_24h": 95,
+	}
+}
+
+// GetMepLimit returns the GB3095-2012 limit of a pollutant tag for a grade
+func GetMepLimit(pollutant string, grade int) (float64, error) {
+	limit, ok := mepLimits[pollutant]
+	if !ok {
+		return 0, errors.New("Invalid pollutant metric")
+	}
+	switch grade {
+	case MepGradeI:
+		return limit.GradeI, nil
+	case MepGradeII:
+		return limit.GradeII, nil
+	}
+	return 0, errors.New("Invalid grade")
+}
+
+// annualTag maps a daily tag to its annual limit tag, e.g. pm25_24h to
+// pm25_annual
+func annualTag(pollutant string) string {
+	for i := len(pollutant) - 1; i >= 0; i-- {
+		if pollutant[i] == '_' {
+			return pollutant[:i] + "_annual"
+		}
+	}
+	return pollutant + "_annual"
+}
+
+// AssessMepAttainment assesses a site-year of daily values(o3_8h: daily max
+// 8-hr) of a pollutant against a grade. The pollutant attains when the
+// percentile of daily values and, if limited, the annual mean are within the
+// limits
+func AssessMepAttainment(pollutant string, daily []float64, grade int) (MepAttainment, error) {
+	result := MepAttainment{Pollutant: pollutant, Days: len(daily)}
+	percentile, ok := mepPercentiles[pollutant]
+	if !ok {
+		return result, errors.New("Invalid pollutant metric")
+	}
+	dailyLimit, err := GetMepLimit(pollutant, grade)
+	if err != nil {
+		return result, err
+	}
+	if len(daily) == 0 {
+		return result, errors.New("No daily values")
+	}
+
+	var sum float64
+	for _, v := range daily {
+		sum += v
+		if v > dailyLimit {
+			result.ExceedanceDays++
+		}
+	}
+	result.Valid = result.Days >= MepAnnualMinDays
+	result.AnnualMean = sum / float64(len(daily))
+	result.Percentile = percentile
+	result.PercentileValue = Percentile(daily, percentile)
+	result.DailyLimit = dailyLimit
+	result.Attained = result.PercentileValue <= dailyLimit
+
+	if annualLimit, err := GetMepLimit(annualTag(pollutant), grade); err == nil {
+		result.AnnualLimit = annualLimit
+		result.Attained = result.Attained && result.AnnualMean <= annualLimit
+	}
+	return result, nil
+}
+
+// AssessMepAttainmentAll assesses daily values of every pollutant
+func AssessMepAttainmentAll(daily map[string][]float64, grade int) (map[string]MepAttainment, error) {
+	result := make(map[string]MepAttainment)
+	for pollutant, values := range daily {
+		attainment, err := AssessMepAttainment(pollutant, values, grade)
+		if err != nil {
+			return result, errors.New(pollutant + ": " + err.Error())
+		}
+		result[pollutant] = attainment
+	}
+	return result, nil
+}
+
+// ExceededLimits returns the pollutants whose concentration exceed the
+// GB3095-2012 limit of the grade
+func (mep *MepPollutant) ExceededLimits(grade int) []string {
+	result := make([]string, 0)
+	for pollutant, concentration := range structConcentrations(mep) {
+		if limit, err := GetMepLimit(pollutant, grade); err == nil && concentration > limit {
+			result = append(result, pollutant)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
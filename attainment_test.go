@@ -0,0 +1,93 @@
+package aqi
+
+import (
+	"testing"
+)
+
+func TestGetMepLimit(t *testing.T) {
+	if v, _ := GetMepLimit("pm25_24h", MepGradeII); v != 75 {
+		t.Errorf("err %f, want 75", v)
+	}
+	if v, _ := GetMepLimit("o3_8h", MepGradeI); v != 100 {
+		t.Errorf("err %f, want 100", v)
+	}
+	if _, err := GetMepLimit("foo", MepGradeI); err == nil {
+		t.Error("fake foo pollutant should raise exception")
+	}
+	if _, err := GetMepLimit("pm25_24h", 3); err == nil {
+		t.Error("grade 3 should raise exception")
+	}
+}
+
+func TestAssessMepAttainment(t *testing.T) {
+	daily := make([]float64, 0)
+	for i := 0; i < 365; i++ {
+		daily = append(daily, 30)
+	}
+	for i := 0; i < 15; i++ {
+		daily[i] = 100
+	}
+	// 15 days above 75 within 5% of 365 days, annual mean 32.9
+	result, err := AssessMepAttainment("pm25_24h", daily, MepGradeII)
+	if err != nil {
+		t.Error(err)
+	}
+	if !result.Valid || result.ExceedanceDays != 15 || result.AnnualLimit != 35 {
+		t.Errorf("err %#v", result)
+	}
+	if !result.Attained {
+		t.Errorf("should be attained, p95 %f mean %f", result.PercentileValue, result.AnnualMean)
+	}
+	result, _ = AssessMepAttainment("pm25_24h", daily, MepGradeI)
+	if result.Attained {
+		t.Error("should not be attained with grade I")
+	}
+
+	// o3 has no annual limit, 90th percentile of daily max 8-hr
+	result, _ = AssessMepAttainment("o3_8h", []float64{100, 120, 140, 150, 170}, MepGradeII)
+	if result.AnnualLimit != 0 || result.Percentile != 90 || result.Attained {
+		t.Errorf("err %#v", result)
+	}
+	if result.Valid {
+		t.Error("5 days should not be valid")
+	}
+
+	if _, err = AssessMepAttainment("so2_1h", daily, MepGradeII); err == nil {
+		t.Error("so2_1h has no annual assessment")
+	}
+	if _, err = AssessMepAttainment("co_24h", nil, MepGradeII); err == nil {
+		t.Error("empty daily values should raise exception")
+	}
+}
+
+func TestAssessMepAttainmentAll(t *testing.T) {
+	result, err := AssessMepAttainmentAll(map[string][]float64{
+		"so2_24h": []float64{10, 20, 30},
+		"co_24h":  []float64{1, 2, 5},
+	}, MepGradeII)
+	if err != nil {
+		t.Error(err)
+	}
+	if !result["so2_24h"].Attained {
+		t.Error("so2_24h should be attained")
+	}
+	if result["co_24h"].Attained {
+		t.Error("co_24h should not be attained")
+	}
+}
+
+func TestMepExceededLimits(t *testing.T) {
+	mep := &MepPollutant{
+		PM25Pollutant24H: 60,
+		PM10Pollutant24H: 65,
+		O3Pollutant8H:    120,
+	}
+	result := mep.ExceededLimits(MepGradeI)
+	if len(result) != 3 {
+		t.Errorf("should be 3 pollutants, but %v", result)
+	}
+	result = mep.ExceededLimits(MepGradeII)
+	if len(result) != 0 {
+		t.Errorf("should be attained, but %v", result)
+	}
+}
@@ -0,0 +1,164 @@
+package aqi
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// columns appended to every row by ProcessCSV, besides "<pollutant>_iaqi"
+const (
+	BatchAQIColumn         = "aqi"
+	BatchCategoryColumn    = "category"
+	BatchResponsibleColumn = "responsible_pollutants"
+	BatchErrorColumn       = "error"
+)
+
+// BatchConfig configures ProcessCSV. Columns maps CSV header names to
+// pollutant tags, headers equal to a pollutant tag of the standard are mapped
+// without configuration
+type BatchConfig struct {
+	Standard Standard
+	Columns  map[string]string
+}
+
+// BatchStats counts rows processed by ProcessCSV
+type BatchStats struct {
+	Rows   int
+	Errors int
+}
+
+// ParseColumnMapping parses "header=pollutant" pairs
+func ParseColumnMapping(pairs []string) (map[string]string, error) {
+	result := make(map[string]string)
+	for _, pair := range pairs {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+			return nil, errors.New("Invalid column mapping " + pair)
+		}
+		result[kv[0]] = kv[1]
+	}
+	return result, nil
+}
+
+// ProcessCSV reads station rows with a header from r and writes them to w
+// enriched with the IAQI of every mapped pollutant, the AQI, category and
+// responsible pollutants. Rows are streamed one by one, an invalid row is
+// written with its error in the error column instead of aborting
+func ProcessCSV(r io.Reader, w io.Writer, config BatchConfig) (BatchStats, error) {
+	var stats BatchStats
+	if config.Standard == nil {
+		return stats, errors.New("Missing standard")
+	}
+	reader := csv.NewReader(r)
+	writer := csv.NewWriter(w)
+
+	header, err := reader.Read()
+	if err != nil {
+		return stats, err
+	}
+
+	calculable := make(map[string]bool)
+	for _, pollutant := range config.Standard.Pollutants() {
+		calculable[pollutant] = true
+	}
+	// index of the column of every pollutant
+	columns := make(map[string]int)
+	for i, name := range header {
+		if pollutant, ok := config.Columns[name]; ok {
+			columns[pollutant] = i
+		} else if calculable[name] {
+			columns[name] = i
+		}
+	}
+	if len(columns) == 0 {
+		return stats, errors.New("No pollutant column")
+	}
+	pollutants := make([]string, 0, len(columns))
+	for _, pollutant := range config.Standard.Pollutants() {
+		if _, ok := columns[pollutant]; ok {
+			pollutants = append(pollutants, pollutant)
+		}
+	}
+	for pollutant := range columns {
+		if !calculable[pollutant] {
+			return stats, errors.New("Standard " + config.Standard.Name() + " does not support " + pollutant)
+		}
+	}
+
+	out := append([]string{}, header...)
+	for _, pollutant := range pollutants {
+		out = append(out, pollutant+"_iaqi")
+	}
+	out = append(out, BatchAQIColumn, BatchCategoryColumn, BatchResponsibleColumn, BatchErrorColumn)
+	if err = writer.Write(out); err != nil {
+		return stats, err
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if _, ok := err.(*csv.ParseError); err != nil && !ok {
+			return stats, err
+		}
+		stats.Rows++
+		// keep columns aligned with the header for malformed rows
+		if len(record) != len(header) {
+			record = append(record, make([]string, len(header))...)[:len(header)]
+		}
+		row, rowErr := processRecord(config.Standard, pollutants, columns, record, err)
+		if rowErr != nil {
+			stats.Errors++
+			row[len(row)-1] = rowErr.Error()
+		}
+		if err = writer.Write(append(record, row...)); err != nil {
+			return stats, err
+		}
+	}
+	writer.Flush()
+	return stats, writer.Error()
+}
+
+// processRecord returns the enriched columns of a record, parseErr is the
+// error reading the record
+func processRecord(std Standard, pollutants []string, columns map[string]int, record []string, parseErr error) ([]string, error) {
+	row := make([]string, len(pollutants)+4)
+	if parseErr != nil {
+		return row, parseErr
+	}
+	concentrations := make(map[string]float64)
+	for _, pollutant := range pollutants {
+		i := columns[pollutant]
+		if i >= len(record) || strings.TrimSpace(record[i]) == "" {
+			continue
+		}
+		v, err := strconv.ParseFloat(strings.TrimSpace(record[i]), 64)
+		if err != nil {
+			return row, errors.New(pollutant + ": invalid number " + record[i])
+		}
+		concentrations[pollutant] = v
+	}
+	if len(concentrations) == 0 {
+		return row, errors.New("No concentration")
+	}
+	result, err := Calculate(std, concentrations)
+	if err != nil {
+		return row, err
+	}
+	for i, pollutant := range pollutants {
+		if v, ok := result.IAQIs[pollutant]; ok {
+			row[i] = strconv.Itoa(v)
+		}
+	}
+	n := len(pollutants)
+	row[n] = strconv.Itoa(result.AQI)
+	if category, err := GetCategory(std, result.AQI); err == nil {
+		row[n+1] = category.Name
+	}
+	row[n+2] = strings.Join(result.ResponsiblePollutants, " ")
+	return row, nil
+}
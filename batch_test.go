@@ -0,0 +1,74 @@
+package aqi
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+)
+
+func TestParseColumnMapping(t *testing.T) {
+	result, err := ParseColumnMapping([]string{"PM2.5=pm25_24h", "PM10=pm10_24h"})
+	if err != nil || result["PM2.5"] != "pm25_24h" || len(result) != 2 {
+		t.Errorf("err %v %v", result, err)
+	}
+	if _, err = ParseColumnMapping([]string{"PM2.5"}); err == nil {
+		t.Error("missing pollutant should raise exception")
+	}
+}
+
+func TestProcessCSV(t *testing.T) {
+	input := `timestamp,station,PM2.5,pm10_24h
+2013-01-15 00:00,1001,44,65
+2013-01-15 00:00,1002,82,113
+2013-01-15 00:00,1003,abc,113
+2013-01-15 00:00,1004,,
+2013-01-15 00:00,1005,82
+`
+	var output bytes.Buffer
+	stats, err := ProcessCSV(strings.NewReader(input), &output, BatchConfig{
+		Standard: MepStandard,
+		Columns:  map[string]string{"PM2.5": "pm25_24h"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Rows != 5 || stats.Errors != 3 {
+		t.Errorf("err %#v", stats)
+	}
+
+	records, err := csv.NewReader(&output).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	header := strings.Join(records[0], ",")
+	if header != "timestamp,station,PM2.5,pm10_24h,pm10_24h_iaqi,pm25_24h_iaqi,aqi,category,responsible_pollutants,error" {
+		t.Errorf("unexpected header %s", header)
+	}
+	if row := strings.Join(records[2], ","); row != "2013-01-15 00:00,1002,82,113,82,109,109,Lightly Polluted,pm25_24h," {
+		t.Errorf("unexpected row %s", row)
+	}
+	if row := records[3]; row[9] != "pm25_24h: invalid number abc" || row[6] != "" {
+		t.Errorf("unexpected row %v", row)
+	}
+	if row := records[4]; row[9] != "No concentration" {
+		t.Errorf("unexpected row %v", row)
+	}
+	if row := records[5]; len(row) != 10 || row[9] == "" {
+		t.Errorf("malformed row should keep aligned with error, %v", row)
+	}
+}
+
+func TestProcessCSVErrors(t *testing.T) {
+	var output bytes.Buffer
+	if _, err := ProcessCSV(strings.NewReader("a,b\n1,2\n"), &output, BatchConfig{Standard: MepStandard}); err == nil {
+		t.Error("no pollutant column should raise exception")
+	}
+	config := BatchConfig{Standard: EpaStandard, Columns: map[string]string{"so2": "so2_24h"}}
+	if _, err := ProcessCSV(strings.NewReader("so2\n1\n"), &output, config); err == nil {
+		t.Error("so2_24h should not be supported by epa")
+	}
+	if _, err := ProcessCSV(strings.NewReader(""), &output, BatchConfig{Standard: MepStandard}); err == nil {
+		t.Error("empty input should raise exception")
+	}
+}
@@ -0,0 +1,120 @@
+package aqi
+
+import (
+	"errors"
+	"strconv"
+)
+
+// BeyondIndex is the individual index of concentrations above the top band
+// of a standard with the BeyondIndexFlag policy, published as "500+" or
+// "Beyond Index"
+const BeyondIndex = 911
+
+// BeyondIndexRejected is the individual index of concentrations above the
+// top band of a standard with the BeyondIndexError policy. MaxAggregate
+// returns it when any individual index is rejected, so the AQI is never
+// under-reported from the other pollutants
+const BeyondIndexRejected = -5
+
+// ErrBeyondIndex is the error of concentrations above the top band of a
+// standard with the BeyondIndexError policy
+var ErrBeyondIndex = errors.New("Concentration value beyond the index")
+
+// BeyondIndexPolicy is how a standard indexes concentrations above its top
+// band
+type BeyondIndexPolicy int
+
+const (
+	// BeyondIndexFlag returns BeyondIndex
+	BeyondIndexFlag BeyondIndexPolicy = iota
+	// BeyondIndexError fails with ErrBeyondIndex and BeyondIndexRejected
+	BeyondIndexError
+	// BeyondIndexCap returns the top of the index scale
+	BeyondIndexCap
+	// BeyondIndexExtrapolate extends the slope of the top band
+	BeyondIndexExtrapolate
+)
+
+func (policy BeyondIndexPolicy) String() string {
+	switch policy {
+	case BeyondIndexFlag:
+		return "flag"
+	case BeyondIndexError:
+		return "error"
+	case BeyondIndexCap:
+		return "cap"
+	case BeyondIndexExtrapolate:
+		return "extrapolate"
+	}
+	return "BeyondIndexPolicy(" + strconv.Itoa(int(policy)) + ")"
+}
+
+func (policy BeyondIndexPolicy) MarshalText() ([]byte, error) {
+	return marshalEnum("beyond index policy", int(policy), 4, policy.String())
+}
+
+func (policy *BeyondIndexPolicy) UnmarshalText(text []byte) error {
+	v, err := unmarshalEnum("beyond index policy", text, 4, func(i int) string { return BeyondIndexPolicy(i).String() })
+	if err == nil {
+		*policy = BeyondIndexPolicy(v)
+	}
+	return err
+}
+
+// BeyondIndexer is implemented by standards declaring their beyond index
+// policy
+type BeyondIndexer interface {
+	BeyondIndexPolicy() BeyondIndexPolicy
+}
+
+// WithBeyondIndexPolicy returns a copy of a built-in standard indexing
+// concentrations above its top band by policy, the registered standard and
+// the package level functions keep the default policy
+func WithBeyondIndexPolicy(std Standard, policy BeyondIndexPolicy) (Standard, error) {
+	switch s := std.(type) {
+	case epaStandard:
+		s.beyondIndex = policy
+		return s, nil
+	case mepStandard:
+		s.beyondIndex = policy
+		return s, nil
+	case psiStandard:
+		s.beyondIndex = policy
+		return s, nil
+	case caiStandard:
+		s.beyondIndex = policy
+		return s, nil
+	case mexStandard:
+		s.beyondIndex = policy
+		return s, nil
+	}
+	return nil, errors.New("Standard " + std.Name() + " has no beyond index policy")
+}
+
+// IsBeyondIndex reports whether an individual index is the BeyondIndex flag
+func IsBeyondIndex(iaqi int) bool {
+	return iaqi == BeyondIndex
+}
+
+// apply indexes a concentration above top, the top band of a pollutant.
+// interpolated is false for the flag and the error like the IAQI functions
+func (policy BeyondIndexPolicy) apply(concentration float64, top Band) (iaqi float64, interpolated bool, err error) {
+	switch policy {
+	case BeyondIndexError:
+		return BeyondIndexRejected, false, ErrBeyondIndex
+	case BeyondIndexCap:
+		return top.IAQITo, true, nil
+	case BeyondIndexExtrapolate:
+		return top.interpolate(concentration)
+	}
+	return BeyondIndex, false, nil
+}
+
+// topBand returns the top band of a pollutant of a standard
+func topBand(std BreakPointer, pollutant string) Band {
+	bands, err := std.BreakPoints(pollutant)
+	if err != nil || len(bands) == 0 {
+		return Band{}
+	}
+	return bands[len(bands)-1]
+}
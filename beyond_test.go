@@ -0,0 +1,103 @@
+package aqi
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBeyondIndexPolicy(t *testing.T) {
+	type Seed struct {
+		Policy BeyondIndexPolicy
+		IAQI   int
+		AQI    int
+	}
+	// pm25_24h 600 is above the top band 350.5-500.4 of 401-500
+	seeds := []Seed{
+		{BeyondIndexFlag, BeyondIndex, BeyondIndex},
+		{BeyondIndexCap, 500, 500},
+		{BeyondIndexExtrapolate, 566, 566},
+		{BeyondIndexError, BeyondIndexRejected, BeyondIndexRejected},
+	}
+	for _, seed := range seeds {
+		std, err := WithBeyondIndexPolicy(EpaStandard, seed.Policy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v := std.(BeyondIndexer).BeyondIndexPolicy(); v != seed.Policy {
+			t.Errorf("epa policy should be %s, but %s", seed.Policy, v)
+		}
+		iaqis := GetAllIAQI(std, &EpaPollutant{PM25Pollutant24H: 600, PM10Pollutant24H: 350})
+		if v := iaqis["pm25_24h"]; v != seed.IAQI {
+			t.Errorf("%s pm25_24h IAQI should be %d, but %d", seed.Policy, seed.IAQI, v)
+		}
+		if v := std.AQI(iaqis); v != seed.AQI {
+			t.Errorf("%s AQI should be %d, but %d", seed.Policy, seed.AQI, v)
+		}
+		if responsible := ResponsiblePollutants(std, iaqis); len(responsible) != 1 || responsible[0] != "pm25_24h" {
+			t.Errorf("%s responsible pollutant should be pm25_24h, but %v", seed.Policy, responsible)
+		}
+		_, err = std.IAQI("pm25_24h", 600)
+		if (err != nil) != (seed.Policy == BeyondIndexError) {
+			t.Errorf("%s should fail only for the error policy, but %v", seed.Policy, err)
+		}
+		// under the top of the table every policy interpolates
+		if v, _ := std.IAQI("pm25_24h", 500.4); v != 500 {
+			t.Errorf("%s pm25_24h 500.4 should be 500, but %d", seed.Policy, v)
+		}
+	}
+
+	// the registered standard and the package level functions keep the flag
+	if v, _ := GetEpaIAQI("pm25_24h", 600); v != BeyondIndex {
+		t.Errorf("GetEpaIAQI should flag pm25_24h 600, but %d", v)
+	}
+	if v := (&EpaPollutant{PM25Pollutant24H: 600}).GetAQI(); v != BeyondIndex {
+		t.Errorf("EpaPollutant GetAQI should flag pm25_24h 600, but %d", v)
+	}
+	if std, _ := GetStandard("epa"); std.(BeyondIndexer).BeyondIndexPolicy() != BeyondIndexFlag {
+		t.Error("registered epa should flag")
+	}
+	if IsBeyondIndex(500) || !IsBeyondIndex(BeyondIndex) {
+		t.Error("only BeyondIndex should be beyond index")
+	}
+}
+
+func TestBeyondIndexRejected(t *testing.T) {
+	// hazardous pm25 must not be reported by the good pm10
+	concentrations := map[string]float64{"pm25_24h": 700, "pm10_24h": 20}
+	for _, name := range []string{"epa", "mep", "cai"} {
+		registered, _ := GetStandard(name)
+		std, _ := WithBeyondIndexPolicy(registered, BeyondIndexError)
+		iaqis := make(map[string]int)
+		for pollutant, v := range concentrations {
+			iaqis[pollutant], _ = std.IAQI(pollutant, v)
+		}
+		if v := std.AQI(iaqis); v != BeyondIndexRejected {
+			t.Errorf("%s AQI should be rejected, but %d", name, v)
+		}
+		if _, err := Calculate(std, concentrations); err == nil || !strings.Contains(err.Error(), ErrBeyondIndex.Error()) {
+			t.Errorf("%s Calculate should fail with ErrBeyondIndex, but %v", name, err)
+		}
+	}
+	if _, err := WithBeyondIndexPolicy(NswStandard, BeyondIndexCap); err == nil {
+		t.Error("nsw has no beyond index policy, should fail with error")
+	}
+}
+
+func TestStandardBeyondIndexPolicy(t *testing.T) {
+	for _, name := range StandardNames() {
+		std, _ := GetStandard(name)
+		// percent standards have no top band
+		if _, ok := std.(*PercentStandard); ok {
+			continue
+		}
+		if _, ok := std.(BeyondIndexer); !ok {
+			t.Errorf("%s should declare its beyond index policy", name)
+		}
+	}
+	if v := MexStandard.(BeyondIndexer).BeyondIndexPolicy(); v != BeyondIndexExtrapolate {
+		t.Errorf("mex should extrapolate by default, but %s", v)
+	}
+	if v := BeyondIndexPolicy(9).String(); v != "BeyondIndexPolicy(9)" {
+		t.Errorf("unknown policy should be BeyondIndexPolicy(9), but %s", v)
+	}
+}
@@ -0,0 +1,271 @@
+package aqi
+
+import (
+	"errors"
+)
+
+const (
+	// pollutants above CaiBadClassified are in "bad" or worse
+	CaiBadClassified = 100
+	// composite additions when two, three or more pollutants are "bad"
+	CaiTwoBadAddition   = 50
+	CaiThreeBadAddition = 75
+)
+
+// South Korea Comprehensive Air-quality Index(CAI)
+type CaiPollutant struct {
+	SO2Pollutant1H   float64 `json:"so2_1h" truncate:"3"`   // ppm
+	NO2Pollutant1H   float64 `json:"no2_1h" truncate:"3"`   // ppm
+	COPollutant1H    float64 `json:"co_1h" truncate:"2"`    // ppm
+	O3Pollutant1H    float64 `json:"o3_1h" truncate:"3"`    // ppm
+	PM10Pollutant24H float64 `json:"pm10_24h" truncate:"0"` // µg/m³
+	PM25Pollutant24H float64 `json:"pm25_24h" truncate:"0"` // µg/m³
+}
+
+type CAIBreakPoint struct {
+	From, To float64
+}
+
+var (
+	caiIAQIs          []CAIBreakPoint
+	caiCategories     []Category
+	caiConcentrations map[string][]CAIBreakPoint
+	caiComputableMaxs map[string]float64
+	caiTruncateRules  map[string]int
+)
+
+type caiStandard struct {
+	beyondIndex BeyondIndexPolicy
+}
+
+// defaultCai is the CaiStandard of the package level functions, it flags
+// concentrations above the top band
+var defaultCai = caiStandard{beyondIndex: BeyondIndexFlag}
+
+// CaiStandard is the South Korea CAI standard
+var CaiStandard Standard = defaultCai
+
+func (caiStandard) Name() string {
+	return "cai"
+}
+
+func (caiStandard) Pollutants() []string {
+	return sortedKeys(caiComputableMaxs)
+}
+
+func (std caiStandard) IAQI(pollutant string, concentration float64) (int, error) {
+	iaqi, interpolated, err := std.iaqi(pollutant, concentration)
+	if !interpolated {
+		return int(iaqi), err
+	}
+	return CaiRoundingPolicy.Index(iaqi), nil
+}
+
+func (caiStandard) AQI(iaqis map[string]int) int {
+	return CaiAggregate(iaqis)
+}
+
+// CaiRoundingPolicy truncates concentrations by the `truncate` struct tags
+// of CaiPollutant and rounds indexes half-up
+var CaiRoundingPolicy RoundingPolicy
+
+func (std caiStandard) FloatIAQI(pollutant string, concentration float64) (float64, error) {
+	iaqi, _, err := std.iaqi(pollutant, concentration)
+	return iaqi, err
+}
+
+func (caiStandard) RoundingPolicy() RoundingPolicy {
+	return CaiRoundingPolicy
+}
+
+func (std caiStandard) BeyondIndexPolicy() BeyondIndexPolicy {
+	return std.beyondIndex
+}
+
+// CaiSanitationPolicy sanitizes concentrations before indexing
+var CaiSanitationPolicy = DefaultSanitationPolicy
+
+func (caiStandard) SanitationPolicy() SanitationPolicy {
+	return CaiSanitationPolicy
+}
+
+func (caiStandard) Unit(pollutant string) (string, error) {
+	if !caiPollutantCalculable(pollutant) {
+		return "", errors.New("Invalid pollutant metric")
+	}
+	return unitOf(ppmUnits, pollutant)
+}
+
+func (caiStandard) BreakPoints(pollutant string) ([]Band, error) {
+	return breakPointBands(caiConcentrations, caiIAQIs, pollutant)
+}
+
+func (caiStandard) Categories() []Category {
+	return caiCategories
+}
+
+func init() {
+	caiCategories = []Category{
+		Category{Level: 1, Name: "Good", From: 0, To: 50,
+			ColorName: "BLUE", Color: Color{R: 50, G: 161, B: 255, C: 80, M: 37, Y: 0, K: 0},
+			Description: "A level that will not impact patients suffering from diseases related to air pollution."},
+		Category{Level: 2, Name: "Moderate", From: 51, To: 100,
+			ColorName: "GREEN", Color: Color{R: 0, G: 199, B: 60, C: 100, M: 0, Y: 70, K: 22},
+			Description: "A level which may have a meager impact on patients in case of chronic exposure."},
+		Category{Level: 3, Name: "Unhealthy", From: 101, To: 250,
+			ColorName: "ORANGE", Color: Color{R: 253, G: 155, B: 90, C: 0, M: 39, Y: 64, K: 1},
+			Description: "A level that may have harmful impacts on patients and members of sensitive groups, and may cause the general public unpleasant feelings."},
+		Category{Level: 4, Name: "Very Unhealthy", From: 251, To: 500,
+			ColorName: "RED", Color: Color{R: 255, G: 89, B: 89, C: 0, M: 65, Y: 65, K: 0},
+			Description: "A level which may have a serious impact on patients and members of sensitive groups in case of acute exposure."},
+	}
+
+	caiIAQIs = []CAIBreakPoint{
+		//0, 50, 100, 250, 500
+		CAIBreakPoint{0, 50},
+		CAIBreakPoint{51, 100},
+		CAIBreakPoint{101, 250},
+		CAIBreakPoint{251, 500},
+	}
+
+	caiConcentrations = make(map[string][]CAIBreakPoint)
+	caiComputableMaxs = make(map[string]float64)
+
+	caiConcentrations["so2_1h"] = []CAIBreakPoint{
+		//0, 0.02, 0.05, 0.15, 1
+		CAIBreakPoint{0.000, 0.020},
+		CAIBreakPoint{0.021, 0.050},
+		CAIBreakPoint{0.051, 0.150},
+		CAIBreakPoint{0.151, 1.000},
+	}
+
+	caiConcentrations["co_1h"] = []CAIBreakPoint{
+		//0, 2, 9, 15, 50
+		CAIBreakPoint{0.00, 2.00},
+		CAIBreakPoint{2.01, 9.00},
+		CAIBreakPoint{9.01, 15.00},
+		CAIBreakPoint{15.01, 50.00},
+	}
+
+	caiConcentrations["o3_1h"] = []CAIBreakPoint{
+		//0, 0.03, 0.09, 0.15, 0.6
+		CAIBreakPoint{0.000, 0.030},
+		CAIBreakPoint{0.031, 0.090},
+		CAIBreakPoint{0.091, 0.150},
+		CAIBreakPoint{0.151, 0.600},
+	}
+
+	caiConcentrations["no2_1h"] = []CAIBreakPoint{
+		//0, 0.03, 0.06, 0.2, 2
+		CAIBreakPoint{0.000, 0.030},
+		CAIBreakPoint{0.031, 0.060},
+		CAIBreakPoint{0.061, 0.200},
+		CAIBreakPoint{0.201, 2.000},
+	}
+
+	caiConcentrations["pm10_24h"] = []CAIBreakPoint{
+		//0, 30, 80, 150, 600
+		CAIBreakPoint{0, 30},
+		CAIBreakPoint{31, 80},
+		CAIBreakPoint{81, 150},
+		CAIBreakPoint{151, 600},
+	}
+
+	caiConcentrations["pm25_24h"] = []CAIBreakPoint{
+		//0, 15, 35, 75, 500
+		CAIBreakPoint{0, 15},
+		CAIBreakPoint{16, 35},
+		CAIBreakPoint{36, 75},
+		CAIBreakPoint{76, 500},
+	}
+
+	for k, v := range caiConcentrations {
+		caiComputableMaxs[k] = v[len(v)-1].To
+	}
+
+	caiTruncateRules = truncateRules(&CaiPollutant{}, caiPollutantCalculable)
+	CaiRoundingPolicy = RoundingPolicy{InputDigits: caiTruncateRules, Output: RoundHalfUp}
+
+	RegisterStandard(CaiStandard)
+}
+
+func caiPollutantCalculable(pollutant string) bool {
+	return caiComputableMaxs[pollutant] > 0
+}
+
+// iaqi returns the unrounded IAQI, interpolated is false for the sentinel
+// values of IAQI
+func (std caiStandard) iaqi(pollutant string, concentration float64) (iaqi float64, interpolated bool, err error) {
+	unit, _ := std.Unit(pollutant)
+	if concentration, iaqi, err = CaiSanitationPolicy.apply(concentration, unit); err != nil || concentration == 0 {
+		return iaqi, false, err
+	}
+	if !caiPollutantCalculable(pollutant) {
+		return -1, false, errors.New("Invalid pollutant metric")
+	}
+	concentration = CaiRoundingPolicy.Input(pollutant, concentration)
+	if concentration > caiComputableMaxs[pollutant] {
+		return std.beyondIndex.apply(concentration, topBand(std, pollutant))
+	}
+	bands, _ := std.BreakPoints(pollutant)
+	band, v, ok := lookupBand(bands, concentration)
+	if !ok {
+		// below the lowest band
+		return -2, false, errors.New("Concentration value out of range")
+	}
+	return band.interpolate(v)
+}
+
+func GetCaiIAQI(pollutant string, concentration float64) (int, error) {
+	return defaultCai.IAQI(pollutant, concentration)
+}
+
+// GetCaiIAQIFloat returns the IAQI of GetCaiIAQI before rounding
+func GetCaiIAQIFloat(pollutant string, concentration float64) (float64, error) {
+	return defaultCai.FloatIAQI(pollutant, concentration)
+}
+
+func GetCaiPM25IAQI(concentration float64) (int, error) {
+	return GetCaiIAQI("pm25_24h", concentration)
+}
+
+func GetCaiPM10IAQI(concentration float64) (int, error) {
+	return GetCaiIAQI("pm10_24h", concentration)
+}
+
+// CaiAggregate takes the max IAQI and raises it by CaiTwoBadAddition when two
+// pollutants are "bad" or worse, by CaiThreeBadAddition when three or more.
+// A beyond index max is returned as it is
+func CaiAggregate(iaqis map[string]int) int {
+	result := MaxAggregate(iaqis)
+	if result == BeyondIndexRejected || IsBeyondIndex(result) {
+		return result
+	}
+	bad := 0
+	for _, v := range iaqis {
+		if v > CaiBadClassified && !IsBeyondIndex(v) {
+			bad++
+		}
+	}
+	switch {
+	case bad == 2:
+		result += CaiTwoBadAddition
+	case bad >= 3:
+		result += CaiThreeBadAddition
+	}
+	return result
+}
+
+// GetAllIAQI is GetAllIAQI of CaiStandard, which keeps the default
+// BeyondIndexFlag whatever WithBeyondIndexPolicy returns
+func (cai *CaiPollutant) GetAllIAQI() map[string]int {
+	return GetAllIAQI(CaiStandard, cai)
+}
+
+func (cai *CaiPollutant) GetAQI() int {
+	return CaiStandard.AQI(cai.GetAllIAQI())
+}
+
+func (cai *CaiPollutant) ResponsiblePollutants() []string {
+	return maxPollutants(cai.GetAllIAQI())
+}
@@ -0,0 +1,85 @@
+package aqi
+
+import (
+	"testing"
+)
+
+var (
+	validCAIPollutants = []string{"so2_1h", "no2_1h", "co_1h", "o3_1h", "pm10_24h", "pm25_24h"}
+)
+
+func TestCaiPollutantCalculable(t *testing.T) {
+	for _, v := range validCAIPollutants {
+		if !caiPollutantCalculable(v) {
+			t.Errorf("%s should be calculable", v)
+		}
+	}
+	for _, v := range []string{"foo", "bar"} {
+		if caiPollutantCalculable(v) {
+			t.Errorf("%s should not be calculable", v)
+		}
+	}
+}
+
+func TestGetCaiIAQI(t *testing.T) {
+	iaqi, err := GetCaiIAQI("foo", 42)
+	if err == nil {
+		t.Error("fake foo pollutant should raise exception")
+	}
+	if iaqi != -1 {
+		t.Error("fake foo pollutant with gt 0 value should return -1")
+	}
+
+	type Seed struct {
+		Pollutant     string
+		Concentration float64
+		Expection     int
+	}
+
+	seeds := []Seed{
+		Seed{"pm25_24h", 15, 50}, Seed{"pm25_24h", 35, 100},
+		Seed{"pm25_24h", 75, 250}, Seed{"pm10_24h", 80, 100},
+		Seed{"o3_1h", 0.09, 100}, Seed{"co_1h", 9, 100},
+		Seed{"so2_1h", 1, 500}, Seed{"no2_1h", 0.0304, 50},
+	}
+
+	for _, seed := range seeds {
+		v, _ := GetCaiIAQI(seed.Pollutant, seed.Concentration)
+		if v != seed.Expection {
+			t.Errorf("%s with %f should return %d, but %d", seed.Pollutant, seed.Concentration, seed.Expection, v)
+		}
+	}
+}
+
+func TestCaiAggregate(t *testing.T) {
+	if v := CaiAggregate(map[string]int{"pm25_24h": 150, "pm10_24h": 90}); v != 150 {
+		t.Errorf("one bad pollutant should return max, but %d", v)
+	}
+	if v := CaiAggregate(map[string]int{"pm25_24h": 150, "pm10_24h": 120}); v != 200 {
+		t.Errorf("two bad pollutants should add 50, but %d", v)
+	}
+	if v := CaiAggregate(map[string]int{"pm25_24h": 150, "pm10_24h": 120, "o3_1h": 101}); v != 225 {
+		t.Errorf("three bad pollutants should add 75, but %d", v)
+	}
+	if v := CaiAggregate(map[string]int{"pm25_24h": BeyondIndex, "pm10_24h": 150}); v != BeyondIndex {
+		t.Errorf("beyond index max should not be raised, but %d", v)
+	}
+	if v := (&CaiPollutant{PM25Pollutant24H: 900, PM10Pollutant24H: 200}).GetAQI(); !IsBeyondIndex(v) {
+		t.Errorf("pm25_24h 900 should be beyond the index, but %d", v)
+	}
+}
+
+func TestCaiGetAQI(t *testing.T) {
+	cai := &CaiPollutant{
+		PM25Pollutant24H: 75,
+		PM10Pollutant24H: 150,
+		O3Pollutant1H:    0.02,
+	}
+	if v := cai.GetAQI(); v != 300 {
+		t.Errorf("should pass with %d", v)
+	}
+	result := cai.ResponsiblePollutants()
+	if len(result) != 2 {
+		t.Errorf("length of result should be 2, but %v", result)
+	}
+}
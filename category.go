@@ -0,0 +1,45 @@
+package aqi
+
+import (
+	"errors"
+)
+
+// Category is an index band of a standard with its color and health advice
+type Category struct {
+	Level       int    `json:"level"`
+	Name        string `json:"name"`
+	From        int    `json:"from"`
+	To          int    `json:"to"`
+	ColorName   string `json:"color_name,omitempty"`
+	Color       Color  `json:"color"`
+	Description string `json:"description,omitempty"`
+	Advice      string `json:"advice,omitempty"`
+}
+
+// Categorizer is implemented by standards classifying index values into
+// categories, categories are ordered by From and the last one covers every
+// index above it
+type Categorizer interface {
+	Categories() []Category
+}
+
+// GetCategory returns the category of an index value
+func GetCategory(std Standard, aqi int) (Category, error) {
+	categorizer, ok := std.(Categorizer)
+	if !ok {
+		return Category{}, errors.New("Standard " + std.Name() + " has no categories")
+	}
+	if aqi < 0 {
+		return Category{}, errors.New("Invalid index value")
+	}
+	categories := categorizer.Categories()
+	if len(categories) == 0 {
+		return Category{}, errors.New("Standard " + std.Name() + " has no categories")
+	}
+	for _, category := range categories {
+		if aqi <= category.To {
+			return category, nil
+		}
+	}
+	return categories[len(categories)-1], nil
+}
@@ -0,0 +1,57 @@
+package aqi
+
+import (
+	"testing"
+)
+
+func TestGetCategory(t *testing.T) {
+	type Seed struct {
+		Standard Standard
+		AQI      int
+		Name     string
+	}
+	seeds := []Seed{
+		Seed{EpaStandard, 0, "Good"}, Seed{EpaStandard, 129, "Unhealthy for Sensitive Groups"},
+		Seed{EpaStandard, BeyondIndex, "Hazardous"}, Seed{MepStandard, 87, "Good"},
+		Seed{MepStandard, 301, "Severely Polluted"}, Seed{PsiStandard, 150, "Unhealthy"},
+		Seed{CaiStandard, 251, "Very Unhealthy"}, Seed{MexStandard, 230, "Extremadamente Mala"},
+		Seed{NswStandard, 120, "Poor"},
+	}
+	for _, seed := range seeds {
+		category, err := GetCategory(seed.Standard, seed.AQI)
+		if err != nil {
+			t.Error(err)
+		}
+		if category.Name != seed.Name {
+			t.Errorf("%s %d should be %s, but %s", seed.Standard.Name(), seed.AQI, seed.Name, category.Name)
+		}
+	}
+	if category, _ := GetCategory(EpaStandard, 160); category.Color.RGBToHex() != "#FF0000" || category.ColorName != "RED" {
+		t.Errorf("epa 160 should be RED, but %s", category.ColorName)
+	}
+	if _, err := GetCategory(MepStandard, -1); err == nil {
+		t.Error("negative index should raise exception")
+	}
+	if _, err := GetCategory(NewPercentStandard("test-empty", map[string]float64{"pm25_24h": 25}, nil), 42); err == nil {
+		t.Error("standard without categories should raise exception")
+	}
+}
+
+func TestCategoriesContinuous(t *testing.T) {
+	for _, name := range StandardNames() {
+		std, _ := GetStandard(name)
+		categorizer, ok := std.(Categorizer)
+		if !ok {
+			continue
+		}
+		categories := categorizer.Categories()
+		if categories[0].From != 0 {
+			t.Errorf("%s categories should start from 0", name)
+		}
+		for i := 1; i < len(categories); i++ {
+			if categories[i].From != categories[i-1].To+1 {
+				t.Errorf("%s category %s should follow %s", name, categories[i].Name, categories[i-1].Name)
+			}
+		}
+	}
+}
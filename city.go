@@ -0,0 +1,177 @@
+package aqi
+
+import (
+	"errors"
+	"sort"
+)
+
+// CityStation is a station of a city with the validity of its
+// concentrations, Valid flags the valid pollutant tags. Every positive
+// concentration is valid when Valid is nil, zero fields are not measured
+type CityStation struct {
+	Station   string
+	Pollutant MepPollutant
+	Valid     map[string]bool
+}
+
+// concentrations returns the valid concentrations of tags of the station
+func (station *CityStation) concentrations(tags []string) map[string]float64 {
+	all := structConcentrations(&station.Pollutant)
+	result := make(map[string]float64)
+	for _, tag := range tags {
+		v := all[tag]
+		if v < 0 {
+			continue
+		}
+		if station.Valid == nil && v > 0 || station.Valid[tag] {
+			result[tag] = v
+		}
+	}
+	return result
+}
+
+// CityAQI is the AQI of a city or reporting area aggregated from its
+// stations. Contributors counts the stations of every pollutant, Stations
+// counts the stations contributing any pollutant, Sources is the station of
+// the max concentration of every pollutant of a reporting area
+type CityAQI struct {
+	Result
+	Contributors map[string]int
+	Stations     int
+	Sources      map[string]string
+}
+
+// MepCityAQI calculates the hourly or daily city AQI of HJ633-2012 from the
+// arithmetic mean of the valid concentrations of its stations per pollutant
+// of the mode, the other fields of the stations are left out
+func MepCityAQI(mode MepReportMode, stations []CityStation) (CityAQI, error) {
+	tags := mode.Pollutants()
+	if mode == MepHourlyReport {
+		tags = append(tags, "so2_24h")
+	}
+	concentrations := make(map[string]map[string]float64)
+	for i := range stations {
+		if _, ok := concentrations[stations[i].Station]; ok {
+			return CityAQI{}, errors.New("Duplicated station " + stations[i].Station)
+		}
+		concentrations[stations[i].Station] = stations[i].concentrations(tags)
+	}
+	return aggregateStations(MepStandard, concentrations, meanAggregate, nil, func(concentrations map[string]float64) (Result, error) {
+		return calculateMepMode(mode, concentrations)
+	})
+}
+
+// calculateMepMode calculates concentrations of a report mode, so2_24h only
+// stands for so2_1h over MepSO21HReported and o3_8h over MepO38HReported is
+// reported by o3_1h, see GetHourlyIAQI and GetDailyIAQI
+func calculateMepMode(mode MepReportMode, concentrations map[string]float64) (Result, error) {
+	reported := make(map[string]float64)
+	for _, tag := range mode.Pollutants() {
+		if v, ok := concentrations[tag]; ok {
+			reported[tag] = v
+		}
+	}
+	if v, ok := reported["so2_1h"]; ok && v > MepSO21HReported {
+		delete(reported, "so2_1h")
+		if v, ok := concentrations["so2_24h"]; ok {
+			reported["so2_24h"] = v
+		}
+	}
+	if v, ok := reported["o3_8h"]; ok && v > MepO38HReported {
+		delete(reported, "o3_8h")
+	}
+	return Calculate(MepStandard, reported)
+}
+
+// CityMeanAQI calculates the AQI of the mean concentrations of stations,
+// stations maps station IDs to their valid concentrations
+func CityMeanAQI(std Standard, stations map[string]map[string]float64) (CityAQI, error) {
+	return aggregateStations(std, stations, meanAggregate, nil, func(concentrations map[string]float64) (Result, error) {
+		return Calculate(std, concentrations)
+	})
+}
+
+func meanAggregate(pollutant string, values map[string]float64) float64 {
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// ReportingAreaAQI calculates the AQI of a reporting area of EPA from the max
+// concentration of its stations per pollutant, stations maps station IDs to
+// their valid concentrations
+func ReportingAreaAQI(std Standard, stations map[string]map[string]float64) (CityAQI, error) {
+	sources := make(map[string]string)
+	return aggregateStations(std, stations, func(pollutant string, values map[string]float64) float64 {
+		// the first station in order of IDs wins a tie
+		ids := make([]string, 0, len(values))
+		for id := range values {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		max := -1.0
+		for _, id := range ids {
+			if values[id] > max {
+				max = values[id]
+				sources[pollutant] = id
+			}
+		}
+		return max
+	}, sources, func(concentrations map[string]float64) (Result, error) {
+		return Calculate(std, concentrations)
+	})
+}
+
+// aggregateStations aggregates the concentrations of every pollutant of
+// stations with aggregate and calculates them with calculate
+func aggregateStations(std Standard, stations map[string]map[string]float64,
+	aggregate func(pollutant string, values map[string]float64) float64, sources map[string]string,
+	calculate func(concentrations map[string]float64) (Result, error)) (CityAQI, error) {
+	calculable := make(map[string]bool)
+	for _, tag := range std.Pollutants() {
+		calculable[tag] = true
+	}
+	values := make(map[string]map[string]float64)
+	contributing := 0
+	for id, concentrations := range stations {
+		contributed := false
+		for pollutant, v := range concentrations {
+			if !calculable[pollutant] {
+				return CityAQI{}, errors.New(id + ": Standard " + std.Name() + " does not support " + pollutant)
+			}
+			if v < 0 {
+				return CityAQI{}, errors.New(id + ": " + pollutant + ": Invalid concentration")
+			}
+			if _, ok := values[pollutant]; !ok {
+				values[pollutant] = make(map[string]float64)
+			}
+			values[pollutant][id] = v
+			contributed = true
+		}
+		if contributed {
+			contributing++
+		}
+	}
+	if len(values) == 0 {
+		return CityAQI{}, errors.New("No concentration")
+	}
+
+	concentrations := make(map[string]float64)
+	contributors := make(map[string]int)
+	for pollutant, byStation := range values {
+		concentrations[pollutant] = aggregate(pollutant, byStation)
+		contributors[pollutant] = len(byStation)
+	}
+	result, err := calculate(concentrations)
+	if err != nil {
+		return CityAQI{}, err
+	}
+	return CityAQI{
+		Result:       result,
+		Contributors: contributors,
+		Stations:     contributing,
+		Sources:      sources,
+	}, nil
+}
@@ -0,0 +1,114 @@
+package aqi
+
+import (
+	"testing"
+)
+
+func TestMepCityAQI(t *testing.T) {
+	stations := []CityStation{
+		{Station: "1001", Pollutant: MepPollutant{PM25Pollutant24H: 70, PM10Pollutant24H: 100}},
+		{Station: "1002", Pollutant: MepPollutant{PM25Pollutant24H: 80, PM10Pollutant24H: 120}},
+		// pm25_24h of 1003 is invalid
+		{Station: "1003", Pollutant: MepPollutant{PM25Pollutant24H: 120, PM10Pollutant24H: 140},
+			Valid: map[string]bool{"pm10_24h": true}},
+	}
+	city, err := MepCityAQI(MepDailyReport, stations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if city.Concentrations["pm25_24h"] != 75 || city.Concentrations["pm10_24h"] != 120 {
+		t.Errorf("concentrations should be averaged, but %v", city.Concentrations)
+	}
+	if city.AQI != 100 || len(city.ResponsiblePollutants) != 1 || city.ResponsiblePollutants[0] != "pm25_24h" {
+		t.Errorf("city AQI should be 100 of pm25_24h, but %d %v", city.AQI, city.ResponsiblePollutants)
+	}
+	if city.Contributors["pm25_24h"] != 2 || city.Contributors["pm10_24h"] != 3 || city.Stations != 3 {
+		t.Errorf("contributors should be pm25_24h 2 pm10_24h 3 of 3 stations, but %v of %d", city.Contributors, city.Stations)
+	}
+	if city.Sources != nil {
+		t.Errorf("city sources should be nil, but %v", city.Sources)
+	}
+
+	stations = append(stations, CityStation{Station: "1001"})
+	if _, err = MepCityAQI(MepDailyReport, stations); err == nil {
+		t.Error("duplicated station should fail with error")
+	}
+	if _, err = MepCityAQI(MepDailyReport, []CityStation{{Station: "1001"}}); err == nil {
+		t.Error("stations without concentration should fail with error")
+	}
+}
+
+func TestMepCityAQIMode(t *testing.T) {
+	stations := []CityStation{
+		{Station: "1001", Pollutant: MepPollutant{NO2Pollutant1H: 100, NO2Pollutant24H: 300, PM25Pollutant24H: 70}},
+		{Station: "1002", Pollutant: MepPollutant{NO2Pollutant1H: 200, PM25Pollutant24H: 80}},
+	}
+	hourly, err := MepCityAQI(MepHourlyReport, stations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := hourly.Concentrations["no2_24h"]; ok || hourly.Concentrations["no2_1h"] != 150 {
+		t.Errorf("hourly city AQI should average no2_1h only, but %v", hourly.Concentrations)
+	}
+	if expected, _ := GetMepIAQI("no2_1h", 150); hourly.AQI != 100 || hourly.IAQIs["no2_1h"] != expected {
+		t.Errorf("hourly city AQI should be 100 with no2_1h %d, but %d %v", expected, hourly.AQI, hourly.IAQIs)
+	}
+
+	daily, err := MepCityAQI(MepDailyReport, stations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, _ := GetMepIAQI("no2_24h", 300)
+	if _, ok := daily.Concentrations["no2_1h"]; ok || daily.AQI != expected || daily.ResponsiblePollutants[0] != "no2_24h" {
+		t.Errorf("daily city AQI should be %d of no2_24h only, but %d %v", expected, daily.AQI, daily.Concentrations)
+	}
+
+	// so2_1h over 800 is reported by so2_24h
+	stations = []CityStation{
+		{Station: "1001", Pollutant: MepPollutant{SO2Pollutant1H: 850, SO2Pollutant24H: 100}},
+		{Station: "1002", Pollutant: MepPollutant{SO2Pollutant1H: 950, SO2Pollutant24H: 200}},
+	}
+	hourly, err = MepCityAQI(MepHourlyReport, stations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, _ = GetMepIAQI("so2_24h", 150)
+	if _, ok := hourly.IAQIs["so2_1h"]; ok || hourly.IAQIs["so2_24h"] != expected {
+		t.Errorf("so2_1h 900 should be reported by so2_24h %d, but %v", expected, hourly.IAQIs)
+	}
+	stations[1].Pollutant.SO2Pollutant1H = 150
+	if hourly, _ = MepCityAQI(MepHourlyReport, stations); len(hourly.IAQIs) != 1 || hourly.IAQIs["so2_1h"] == 0 {
+		t.Errorf("so2_1h 500 should be reported alone, but %v", hourly.IAQIs)
+	}
+}
+
+func TestReportingAreaAQI(t *testing.T) {
+	area, err := ReportingAreaAQI(EpaStandard, map[string]map[string]float64{
+		"A": {"pm25_24h": 12, "o3_8h": 0.05},
+		"B": {"pm25_24h": 35.4},
+		"C": {},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if area.AQI != 100 || area.IAQIs["o3_8h"] != 42 || area.ResponsiblePollutants[0] != "pm25_24h" {
+		t.Errorf("reporting area AQI should be 100 of pm25_24h, but %v", area.Result)
+	}
+	if area.Sources["pm25_24h"] != "B" || area.Sources["o3_8h"] != "A" {
+		t.Errorf("sources should be pm25_24h B o3_8h A, but %v", area.Sources)
+	}
+	if area.Contributors["pm25_24h"] != 2 || area.Stations != 2 {
+		t.Errorf("contributors should be pm25_24h 2 of 2 stations, but %v of %d", area.Contributors, area.Stations)
+	}
+
+	seeds := []map[string]map[string]float64{
+		{"A": {"foo": 12}},
+		{"A": {"pm25_24h": -1}},
+		{},
+	}
+	for _, seed := range seeds {
+		if _, err = ReportingAreaAQI(EpaStandard, seed); err == nil {
+			t.Errorf("%v should fail with error", seed)
+		}
+	}
+}
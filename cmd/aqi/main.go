@@ -0,0 +1,359 @@
+// Command aqi calculates air quality indexes.
+//
+//	aqi iaqi --std mep pm25_24h 64
+//	aqi aqi --std epa pm25_24h=40.9 o3_8h=0.087
+//	echo '{"pm25_24h": 40.9}' | aqi aqi --std epa --json
+//	aqi category --std mep 87
+//	aqi inverse --std mep pm25_24h 87
+//	aqi pollutants --std mep
+//	aqi breakpoints --std mep pm25_24h
+//	aqi batch --std mep PM2.5=pm25_24h PM10=pm10_24h < stations.csv > result.csv
+//	aqi standards
+//	aqi serve :8080
+//	aqi openapi
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	aqi "github.com/elvuel/go-aqi"
+	"github.com/elvuel/go-aqi/server"
+)
+
+const usage = `Usage: aqi <command> [--std name] [--json] [args]
+
+Commands:
+  iaqi <pollutant> <concentration>   individual index of a pollutant
+  aqi [pollutant=concentration ...]  composite index, reads a JSON object from stdin without args
+  category <aqi>                     category, color and advice of an index value
+  inverse <pollutant> <iaqi>         concentration of an individual index
+  pollutants                         pollutants of a standard
+  breakpoints [pollutant ...]        break point tables of a standard
+  batch [column=pollutant ...]       enrich station CSV from stdin to stdout, columns named
+                                     by pollutant tags are mapped without args
+  standards                          registered standards
+  serve [addr]                       serve the HTTP JSON API, addr defaults to :8080
+  openapi                            OpenAPI document of the HTTP JSON API
+`
+
+type command func(std aqi.Standard, args []string, stdin io.Reader, out *output) error
+
+var commands = map[string]command{
+	"iaqi":        iaqiCommand,
+	"aqi":         aqiCommand,
+	"category":    categoryCommand,
+	"inverse":     inverseCommand,
+	"pollutants":  pollutantsCommand,
+	"breakpoints": breakpointsCommand,
+	"batch":       batchCommand,
+	"standards":   standardsCommand,
+	"serve":       serveCommand,
+	"openapi":     openapiCommand,
+}
+
+// output writes either JSON or plain text
+type output struct {
+	w    io.Writer
+	json bool
+}
+
+func (out *output) print(v interface{}, text func(w io.Writer)) error {
+	if out.json {
+		encoder := json.NewEncoder(out.w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	}
+	text(out.w)
+	return nil
+}
+
+type categoryOutput struct {
+	Level       int    `json:"level"`
+	Name        string `json:"name"`
+	From        int    `json:"from"`
+	To          int    `json:"to"`
+	ColorName   string `json:"color_name"`
+	Color       string `json:"color"`
+	Description string `json:"description,omitempty"`
+	Advice      string `json:"advice,omitempty"`
+}
+
+func newCategoryOutput(std aqi.Standard, index int) *categoryOutput {
+	category, err := aqi.GetCategory(std, index)
+	if err != nil {
+		return nil
+	}
+	return &categoryOutput{
+		Level:       category.Level,
+		Name:        category.Name,
+		From:        category.From,
+		To:          category.To,
+		ColorName:   category.ColorName,
+		Color:       category.Color.RGBToHex(),
+		Description: category.Description,
+		Advice:      category.Advice,
+	}
+}
+
+func (c *categoryOutput) String() string {
+	if c == nil {
+		return ""
+	}
+	s := c.Name
+	if c.ColorName != "" {
+		s += fmt.Sprintf(" (%s %s)", c.ColorName, c.Color)
+	}
+	if c.Advice != "" {
+		s += "\n" + c.Advice
+	}
+	return s
+}
+
+type bandOutput struct {
+	IAQIFrom float64 `json:"iaqi_from"`
+	IAQITo   float64 `json:"iaqi_to"`
+	From     float64 `json:"from"`
+	To       float64 `json:"to"`
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "aqi:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdin io.Reader, stdout io.Writer) error {
+	if len(args) == 0 {
+		return errors.New("missing command\n" + usage)
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		return errors.New("unknown command " + args[0] + "\n" + usage)
+	}
+	flags := flag.NewFlagSet(args[0], flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	name := flags.String("std", "mep", "standard name, one of "+strings.Join(aqi.StandardNames(), ", "))
+	asJSON := flags.Bool("json", false, "output JSON")
+	if err := flags.Parse(args[1:]); err != nil {
+		return errors.New(err.Error() + "\n" + usage)
+	}
+	std, err := aqi.GetStandard(*name)
+	if err != nil {
+		return err
+	}
+	return cmd(std, flags.Args(), stdin, &output{w: stdout, json: *asJSON})
+}
+
+func parseFloat(s string) (float64, error) {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, errors.New("invalid number " + s)
+	}
+	return v, nil
+}
+
+func iaqiCommand(std aqi.Standard, args []string, stdin io.Reader, out *output) error {
+	if len(args) != 2 {
+		return errors.New("usage: aqi iaqi <pollutant> <concentration>")
+	}
+	concentration, err := parseFloat(args[1])
+	if err != nil {
+		return err
+	}
+	iaqi, err := std.IAQI(args[0], concentration)
+	if err != nil {
+		return err
+	}
+	category := newCategoryOutput(std, iaqi)
+	return out.print(struct {
+		Standard      string          `json:"standard"`
+		Pollutant     string          `json:"pollutant"`
+		Concentration float64         `json:"concentration"`
+		IAQI          int             `json:"iaqi"`
+		Category      *categoryOutput `json:"category,omitempty"`
+	}{std.Name(), args[0], concentration, iaqi, category}, func(w io.Writer) {
+		fmt.Fprintf(w, "%s %s %v: %d\n", std.Name(), args[0], concentration, iaqi)
+		if category != nil {
+			fmt.Fprintln(w, category)
+		}
+	})
+}
+
+// readConcentrations reads pollutant=concentration pairs, or a JSON object
+// from stdin without args
+func readConcentrations(args []string, stdin io.Reader) (map[string]float64, error) {
+	concentrations := make(map[string]float64)
+	if len(args) == 0 {
+		if err := json.NewDecoder(stdin).Decode(&concentrations); err != nil {
+			return nil, errors.New("invalid JSON input: " + err.Error())
+		}
+		return concentrations, nil
+	}
+	for _, arg := range args {
+		pair := strings.SplitN(arg, "=", 2)
+		if len(pair) != 2 {
+			return nil, errors.New("invalid pair " + arg + ", want pollutant=concentration")
+		}
+		v, err := parseFloat(pair[1])
+		if err != nil {
+			return nil, err
+		}
+		concentrations[pair[0]] = v
+	}
+	return concentrations, nil
+}
+
+func aqiCommand(std aqi.Standard, args []string, stdin io.Reader, out *output) error {
+	concentrations, err := readConcentrations(args, stdin)
+	if err != nil {
+		return err
+	}
+	result, err := aqi.Calculate(std, concentrations)
+	if err != nil {
+		return err
+	}
+	category := newCategoryOutput(std, result.AQI)
+	return out.print(struct {
+		aqi.Result
+		Category *categoryOutput `json:"category,omitempty"`
+	}{result, category}, func(w io.Writer) {
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		for _, pollutant := range std.Pollutants() {
+			if v, ok := result.IAQIs[pollutant]; ok {
+				fmt.Fprintf(tw, "%s\t%v\t%d\n", pollutant, concentrations[pollutant], v)
+			}
+		}
+		tw.Flush()
+		fmt.Fprintf(w, "AQI: %d\n", result.AQI)
+		fmt.Fprintf(w, "Responsible: %s\n", strings.Join(result.ResponsiblePollutants, ", "))
+		if category != nil {
+			fmt.Fprintln(w, category)
+		}
+	})
+}
+
+func categoryCommand(std aqi.Standard, args []string, stdin io.Reader, out *output) error {
+	if len(args) != 1 {
+		return errors.New("usage: aqi category <aqi>")
+	}
+	index, err := strconv.Atoi(args[0])
+	if err != nil {
+		return errors.New("invalid index " + args[0])
+	}
+	if _, err = aqi.GetCategory(std, index); err != nil {
+		return err
+	}
+	category := newCategoryOutput(std, index)
+	return out.print(category, func(w io.Writer) {
+		fmt.Fprintln(w, category)
+	})
+}
+
+func inverseCommand(std aqi.Standard, args []string, stdin io.Reader, out *output) error {
+	if len(args) != 2 {
+		return errors.New("usage: aqi inverse <pollutant> <iaqi>")
+	}
+	iaqi, err := strconv.Atoi(args[1])
+	if err != nil {
+		return errors.New("invalid index " + args[1])
+	}
+	concentration, err := aqi.InverseIAQI(std, args[0], iaqi)
+	if err != nil {
+		return err
+	}
+	return out.print(struct {
+		Standard      string  `json:"standard"`
+		Pollutant     string  `json:"pollutant"`
+		IAQI          int     `json:"iaqi"`
+		Concentration float64 `json:"concentration"`
+	}{std.Name(), args[0], iaqi, concentration}, func(w io.Writer) {
+		fmt.Fprintf(w, "%s %s %d: %s\n", std.Name(), args[0], iaqi, strconv.FormatFloat(aqi.Round(concentration, 3), 'f', -1, 64))
+	})
+}
+
+func pollutantsCommand(std aqi.Standard, args []string, stdin io.Reader, out *output) error {
+	pollutants := std.Pollutants()
+	return out.print(pollutants, func(w io.Writer) {
+		fmt.Fprintln(w, strings.Join(pollutants, "\n"))
+	})
+}
+
+func breakpointsCommand(std aqi.Standard, args []string, stdin io.Reader, out *output) error {
+	breakPointer, ok := std.(aqi.BreakPointer)
+	if !ok {
+		return errors.New("standard " + std.Name() + " has no break points")
+	}
+	pollutants := args
+	if len(pollutants) == 0 {
+		pollutants = std.Pollutants()
+	}
+	tables := make(map[string][]bandOutput)
+	for _, pollutant := range pollutants {
+		bands, err := breakPointer.BreakPoints(pollutant)
+		if err != nil {
+			return errors.New(pollutant + ": " + err.Error())
+		}
+		table := make([]bandOutput, 0, len(bands))
+		for _, band := range bands {
+			table = append(table, bandOutput(band))
+		}
+		tables[pollutant] = table
+	}
+	return out.print(tables, func(w io.Writer) {
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "POLLUTANT\tIAQI\tCONCENTRATION")
+		for _, pollutant := range pollutants {
+			for _, band := range tables[pollutant] {
+				fmt.Fprintf(tw, "%s\t%v-%v\t%v-%v\n", pollutant, band.IAQIFrom, band.IAQITo, band.From, band.To)
+			}
+		}
+		tw.Flush()
+	})
+}
+
+func standardsCommand(std aqi.Standard, args []string, stdin io.Reader, out *output) error {
+	names := aqi.StandardNames()
+	return out.print(names, func(w io.Writer) {
+		fmt.Fprintln(w, strings.Join(names, "\n"))
+	})
+}
+
+func batchCommand(std aqi.Standard, args []string, stdin io.Reader, out *output) error {
+	columns, err := aqi.ParseColumnMapping(args)
+	if err != nil {
+		return err
+	}
+	stats, err := aqi.ProcessCSV(stdin, out.w, aqi.BatchConfig{Standard: std, Columns: columns})
+	if err != nil {
+		return err
+	}
+	if stats.Errors > 0 {
+		fmt.Fprintf(os.Stderr, "aqi: %d of %d rows failed\n", stats.Errors, stats.Rows)
+	}
+	return nil
+}
+
+func serveCommand(std aqi.Standard, args []string, stdin io.Reader, out *output) error {
+	addr := ":8080"
+	if len(args) > 0 {
+		addr = args[0]
+	}
+	fmt.Fprintln(os.Stderr, "aqi: serving on", addr)
+	return http.ListenAndServe(addr, server.NewHandler())
+}
+
+func openapiCommand(std aqi.Standard, args []string, stdin io.Reader, out *output) error {
+	encoder := json.NewEncoder(out.w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(server.OpenAPI())
+}
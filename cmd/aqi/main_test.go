@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func runString(t *testing.T, stdin string, args ...string) (string, error) {
+	var out bytes.Buffer
+	err := run(args, strings.NewReader(stdin), &out)
+	return out.String(), err
+}
+
+func TestIAQICommand(t *testing.T) {
+	out, err := runString(t, "", "iaqi", "--std", "mep", "pm25_24h", "64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(out, "mep pm25_24h 64: 87\nGood (YELLOW #FFFF00)") {
+		t.Errorf("unexpected output %q", out)
+	}
+
+	out, _ = runString(t, "", "iaqi", "--std", "epa", "--json", "pm25_24h", "40.9")
+	var v struct {
+		IAQI     int `json:"iaqi"`
+		Category struct {
+			Name string `json:"name"`
+		} `json:"category"`
+	}
+	if err = json.Unmarshal([]byte(out), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.IAQI != 114 || v.Category.Name != "Unhealthy for Sensitive Groups" {
+		t.Errorf("unexpected output %q", out)
+	}
+
+	if _, err = runString(t, "", "iaqi", "--std", "mep", "foo", "64"); err == nil {
+		t.Error("fake foo pollutant should raise exception")
+	}
+	if _, err = runString(t, "", "iaqi", "pm25_24h", "abc"); err == nil {
+		t.Error("invalid concentration should raise exception")
+	}
+}
+
+func TestAQICommand(t *testing.T) {
+	out, err := runString(t, "", "aqi", "--std", "epa", "co_8h=8.4", "o3_8h=0.08742", "pm25_24h=40.9")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "AQI: 129\nResponsible: o3_8h\n") {
+		t.Errorf("unexpected output %q", out)
+	}
+
+	out, err = runString(t, `{"pm25_24h": 82, "pm10_24h": 113}`, "aqi", "--json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v struct {
+		Standard string         `json:"standard"`
+		AQI      int            `json:"aqi"`
+		IAQIs    map[string]int `json:"iaqis"`
+	}
+	if err = json.Unmarshal([]byte(out), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Standard != "mep" || v.AQI != 109 || v.IAQIs["pm10_24h"] != 82 {
+		t.Errorf("unexpected output %q", out)
+	}
+
+	if _, err = runString(t, "", "aqi", "pm25_24h"); err == nil {
+		t.Error("invalid pair should raise exception")
+	}
+	if _, err = runString(t, "{", "aqi"); err == nil {
+		t.Error("invalid JSON should raise exception")
+	}
+}
+
+func TestInverseCommand(t *testing.T) {
+	out, err := runString(t, "", "inverse", "--std", "mep", "pm25_24h", "87")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "mep pm25_24h 87: 64.653\n" {
+		t.Errorf("unexpected output %q", out)
+	}
+}
+
+func TestListCommands(t *testing.T) {
+	out, _ := runString(t, "", "standards")
+	for _, name := range []string{"cai", "epa", "mep", "mex", "nsw", "psi"} {
+		if !strings.Contains(out, name+"\n") {
+			t.Errorf("%s should be listed in %q", name, out)
+		}
+	}
+	out, _ = runString(t, "", "pollutants", "--std", "epa", "--json")
+	var pollutants []string
+	if err := json.Unmarshal([]byte(out), &pollutants); err != nil || len(pollutants) != 7 {
+		t.Errorf("unexpected output %q", out)
+	}
+	out, _ = runString(t, "", "breakpoints", "--std", "mep", "pm25_24h")
+	if !strings.Contains(out, "pm25_24h   401-500  351-500") {
+		t.Errorf("unexpected output %q", out)
+	}
+	out, _ = runString(t, "", "category", "--std", "epa", "160")
+	if !strings.HasPrefix(out, "Unhealthy (RED #FF0000)") {
+		t.Errorf("unexpected output %q", out)
+	}
+}
+
+func TestUnknownCommand(t *testing.T) {
+	if _, err := runString(t, ""); err == nil {
+		t.Error("missing command should raise exception")
+	}
+	if _, err := runString(t, "", "foo"); err == nil {
+		t.Error("unknown command should raise exception")
+	}
+	if _, err := runString(t, "", "standards", "--std", "foo"); err == nil {
+		t.Error("unknown standard should raise exception")
+	}
+}
+
+func TestBatchCommand(t *testing.T) {
+	input := "station,PM2.5,pm10_24h\n1001,82,113\n"
+	out, err := runString(t, input, "batch", "--std", "mep", "PM2.5=pm25_24h")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(out, "\n1001,82,113,82,109,109,Lightly Polluted,pm25_24h,\n") {
+		t.Errorf("unexpected output %q", out)
+	}
+	if _, err = runString(t, input, "batch", "PM2.5"); err == nil {
+		t.Error("invalid column mapping should raise exception")
+	}
+}
+
+func TestOpenAPICommand(t *testing.T) {
+	out, err := runString(t, "", "openapi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v map[string]interface{}
+	if err = json.Unmarshal([]byte(out), &v); err != nil || v["openapi"] != "3.0.3" {
+		t.Errorf("unexpected output %q", out)
+	}
+}
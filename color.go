@@ -1,7 +1,11 @@
 package aqi
 
 import (
+	"errors"
 	"fmt"
+	"math"
+	"strconv"
+	"strings"
 )
 
 type Color struct {
@@ -21,3 +25,223 @@ type EpaColor struct {
 	Name string
 	Color
 }
+
+type MexColor struct {
+	Name string
+	Color
+}
+
+// NewRGBColor returns a color of RGB with its CMYK derived by RGBToCMYK
+func NewRGBColor(r, g, b uint8) Color {
+	color := Color{R: r, G: g, B: b}
+	color.C, color.M, color.Y, color.K = color.RGBToCMYK()
+	return color
+}
+
+// NewCMYKColor returns a color of CMYK in percents with its RGB derived by
+// CMYKToRGB
+func NewCMYKColor(c, m, y, k uint8) Color {
+	color := Color{C: c, M: m, Y: y, K: k}
+	color.R, color.G, color.B = color.CMYKToRGB()
+	return color
+}
+
+// RGBToCMYK converts the RGB of the color to CMYK in percents. The CMYK
+// stored by the standards are their print values, which may differ
+func (color Color) RGBToCMYK() (c, m, y, k uint8) {
+	r, g, b := float64(color.R)/255, float64(color.G)/255, float64(color.B)/255
+	max := math.Max(r, math.Max(g, b))
+	if max == 0 {
+		return 0, 0, 0, 100
+	}
+	percent := func(v float64) uint8 { return uint8(math.Round((max - v) / max * 100)) }
+	return percent(r), percent(g), percent(b), uint8(math.Round((1 - max) * 100))
+}
+
+// CMYKToRGB converts the CMYK in percents of the color to RGB
+func (color Color) CMYKToRGB() (r, g, b uint8) {
+	k := 1 - math.Min(float64(color.K), 100)/100
+	scale := func(v uint8) uint8 { return uint8(math.Round(255 * (1 - math.Min(float64(v), 100)/100) * k)) }
+	return scale(color.C), scale(color.M), scale(color.Y)
+}
+
+// HSL converts the RGB of the color to hue in degrees [0, 360), saturation
+// and lightness in [0, 1]
+func (color Color) HSL() (h, s, l float64) {
+	r, g, b := float64(color.R)/255, float64(color.G)/255, float64(color.B)/255
+	max, min := math.Max(r, math.Max(g, b)), math.Min(r, math.Min(g, b))
+	l = (max + min) / 2
+	d := max - min
+	if d == 0 {
+		return 0, 0, l
+	}
+	s = d / (1 - math.Abs(2*l-1))
+	switch max {
+	case r:
+		h = math.Mod((g-b)/d+6, 6)
+	case g:
+		h = (b-r)/d + 2
+	default:
+		h = (r-g)/d + 4
+	}
+	return h * 60, s, l
+}
+
+// NewHSLColor returns a color of hue in degrees, saturation and lightness in
+// [0, 1] with its CMYK derived by RGBToCMYK
+func NewHSLColor(h, s, l float64) Color {
+	h = math.Mod(math.Mod(h, 360)+360, 360) / 60
+	s, l = clamp01(s), clamp01(l)
+	c := (1 - math.Abs(2*l-1)) * s
+	x := c * (1 - math.Abs(math.Mod(h, 2)-1))
+	var r, g, b float64
+	switch int(h) {
+	case 0:
+		r, g = c, x
+	case 1:
+		r, g = x, c
+	case 2:
+		g, b = c, x
+	case 3:
+		g, b = x, c
+	case 4:
+		r, b = x, c
+	default:
+		r, b = c, x
+	}
+	m := l - c/2
+	return NewRGBColor(channel(r+m), channel(g+m), channel(b+m))
+}
+
+// CSS returns the color in CSS rgb() notation, e.g. "rgb(0, 228, 0)"
+func (color Color) CSS() string {
+	return fmt.Sprintf("rgb(%d, %d, %d)", color.R, color.G, color.B)
+}
+
+// HSLString returns the color in CSS hsl() notation, e.g.
+// "hsl(120, 100%, 44.7%)"
+func (color Color) HSLString() string {
+	h, s, l := color.HSL()
+	return "hsl(" + formatDecimal(h) + ", " + formatDecimal(s*100) + "%, " + formatDecimal(l*100) + "%)"
+}
+
+func formatDecimal(v float64) string {
+	return strconv.FormatFloat(math.Round(v*10)/10, 'f', -1, 64)
+}
+
+// ParseCSSColor parses a color in CSS "#RGB", "#RRGGBB", rgb() or hsl()
+// notation, its CMYK is derived by RGBToCMYK
+func ParseCSSColor(s string) (Color, error) {
+	v := strings.ToLower(strings.TrimSpace(s))
+	invalid := errors.New("Invalid CSS color " + s)
+	switch {
+	case strings.HasPrefix(v, "#") && len(v) == 4:
+		v = "#" + strings.Repeat(v[1:2], 2) + strings.Repeat(v[2:3], 2) + strings.Repeat(v[3:4], 2)
+		fallthrough
+	case strings.HasPrefix(v, "#"):
+		color, err := ParseHexColor(v)
+		if err != nil {
+			return Color{}, invalid
+		}
+		return NewRGBColor(color.R, color.G, color.B), nil
+	case strings.HasPrefix(v, "rgb(") && strings.HasSuffix(v, ")"):
+		args, ok := cssArgs(v[4:len(v)-1], false)
+		if !ok {
+			return Color{}, invalid
+		}
+		return NewRGBColor(channel(args[0]/255), channel(args[1]/255), channel(args[2]/255)), nil
+	case strings.HasPrefix(v, "hsl(") && strings.HasSuffix(v, ")"):
+		args, ok := cssArgs(v[4:len(v)-1], true)
+		if !ok {
+			return Color{}, invalid
+		}
+		return NewHSLColor(args[0], args[1]/100, args[2]/100), nil
+	}
+	return Color{}, invalid
+}
+
+// cssArgs parses the 3 comma or space separated numbers of a CSS color
+// function, the last 2 are percents when percents is true
+func cssArgs(s string, percents bool) ([]float64, bool) {
+	fields := strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' })
+	if len(fields) != 3 {
+		return nil, false
+	}
+	result := make([]float64, 3)
+	for i, field := range fields {
+		if percents && i > 0 {
+			if !strings.HasSuffix(field, "%") {
+				return nil, false
+			}
+			field = field[:len(field)-1]
+		}
+		v, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return nil, false
+		}
+		result[i] = v
+	}
+	return result, true
+}
+
+// OKLab converts the RGB of the color to the perceptual OKLab space,
+// lightness l in [0, 1]
+func (color Color) OKLab() (l, a, b float64) {
+	r, g, bl := linearize(color.R), linearize(color.G), linearize(color.B)
+	lc := math.Cbrt(0.4122214708*r + 0.5363325363*g + 0.0514459929*bl)
+	mc := math.Cbrt(0.2119034982*r + 0.6806995451*g + 0.1073969566*bl)
+	sc := math.Cbrt(0.0883024619*r + 0.2817188376*g + 0.6299787005*bl)
+	return 0.2104542553*lc + 0.7936177850*mc - 0.0040720468*sc,
+		1.9779984951*lc - 2.4285922050*mc + 0.4505937099*sc,
+		0.0259040371*lc + 0.7827717662*mc - 0.8086757660*sc
+}
+
+// NewOKLabColor returns a color of OKLab, clipped to RGB, with its CMYK
+// derived by RGBToCMYK
+func NewOKLabColor(l, a, b float64) Color {
+	lc := cube(l + 0.3963377774*a + 0.2158037573*b)
+	mc := cube(l - 0.1055613458*a - 0.0638541728*b)
+	sc := cube(l - 0.0894841775*a - 1.2914855480*b)
+	return NewRGBColor(
+		delinearize(4.0767416621*lc-3.3077115913*mc+0.2309699292*sc),
+		delinearize(-1.2684380046*lc+2.6097574011*mc-0.3413193965*sc),
+		delinearize(-0.0041960863*lc-0.7034186147*mc+1.7076147010*sc),
+	)
+}
+
+// InterpolateColor blends two colors in OKLab, t in [0, 1] goes from from to
+// to
+func InterpolateColor(from, to Color, t float64) Color {
+	t = clamp01(t)
+	l1, a1, b1 := from.OKLab()
+	l2, a2, b2 := to.OKLab()
+	return NewOKLabColor(l1+(l2-l1)*t, a1+(a2-a1)*t, b1+(b2-b1)*t)
+}
+
+func linearize(v uint8) float64 {
+	c := float64(v) / 255
+	if c <= 0.04045 {
+		return c / 12.92
+	}
+	return math.Pow((c+0.055)/1.055, 2.4)
+}
+
+func delinearize(c float64) uint8 {
+	if c <= 0.0031308 {
+		return channel(12.92 * c)
+	}
+	return channel(1.055*math.Pow(c, 1/2.4) - 0.055)
+}
+
+func cube(v float64) float64 {
+	return v * v * v
+}
+
+func clamp01(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
+
+// channel converts a channel in [0, 1] to [0, 255]
+func channel(v float64) uint8 {
+	return uint8(math.Round(clamp01(v) * 255))
+}
@@ -10,3 +10,64 @@ func TestRGBToHex(t *testing.T) {
 		t.Errorf("err = %s, want %s", hex, "#3FFF76")
 	}
 }
+
+func TestColorConversions(t *testing.T) {
+	orange := NewRGBColor(255, 126, 0)
+	if orange.C != 0 || orange.M != 51 || orange.Y != 100 || orange.K != 0 {
+		t.Errorf("orange CMYK should be 0 51 100 0, but %+v", orange)
+	}
+	if c := NewCMYKColor(0, 51, 100, 0); c.R != 255 || c.G != 125 || c.B != 0 {
+		t.Errorf("CMYK 0 51 100 0 should be 255 125 0, but %+v", c)
+	}
+	if c, m, y, k := (Color{}).RGBToCMYK(); c != 0 || m != 0 || y != 0 || k != 100 {
+		t.Errorf("black should be K 100, but %d %d %d %d", c, m, y, k)
+	}
+	green := Color{R: 0, G: 228, B: 0}
+	if v := green.CSS(); v != "rgb(0, 228, 0)" {
+		t.Errorf("green should be rgb(0, 228, 0), but %s", v)
+	}
+	if v := green.HSLString(); v != "hsl(120, 100%, 44.7%)" {
+		t.Errorf("green should be hsl(120, 100%%, 44.7%%), but %s", v)
+	}
+	for _, v := range []uint8{0, 1, 37, 128, 200, 255} {
+		color := NewRGBColor(v, 255-v, v/2)
+		if c := NewHSLColor(color.HSL()); c != color {
+			t.Errorf("%s should round trip HSL, but %s", color.RGBToHex(), c.RGBToHex())
+		}
+		if c := NewOKLabColor(color.OKLab()); c != color {
+			t.Errorf("%s should round trip OKLab, but %s", color.RGBToHex(), c.RGBToHex())
+		}
+	}
+}
+
+func TestParseCSSColor(t *testing.T) {
+	seeds := map[string]string{
+		"#0f0": "#00FF00", "#FF7E00": "#FF7E00", "rgb(255, 126, 0)": "#FF7E00", "RGB(255 126 0)": "#FF7E00",
+		"hsl(120, 100%, 44.7%)": "#00E400", "hsl(-240 100% 44.7%)": "#00E400",
+	}
+	for s, expected := range seeds {
+		color, err := ParseCSSColor(s)
+		if err != nil || color.RGBToHex() != expected {
+			t.Errorf("%s should be %s, but %s %v", s, expected, color.RGBToHex(), err)
+		}
+	}
+	for _, s := range []string{"", "red", "#12345", "rgb(1, 2)", "rgb(a, b, c)", "hsl(120, 100, 50)"} {
+		if _, err := ParseCSSColor(s); err == nil {
+			t.Errorf("%q should fail with error", s)
+		}
+	}
+}
+
+func TestInterpolateColor(t *testing.T) {
+	white, black := NewRGBColor(255, 255, 255), NewRGBColor(0, 0, 0)
+	if c := InterpolateColor(white, black, 0); c != white {
+		t.Errorf("t 0 should be white, but %s", c.RGBToHex())
+	}
+	if c := InterpolateColor(white, black, 2); c != black {
+		t.Errorf("t beyond 1 should be black, but %s", c.RGBToHex())
+	}
+	// the perceptual middle gray, darker than the RGB middle #808080
+	if c := InterpolateColor(white, black, 0.5); c.RGBToHex() != "#636363" {
+		t.Errorf("middle should be #636363, but %s", c.RGBToHex())
+	}
+}
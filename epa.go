@@ -2,6 +2,7 @@ package aqi
 
 import (
 	"errors"
+	"math"
 	"reflect"
 	"strconv"
 )
@@ -17,17 +18,89 @@ type EpaPollutant struct {
 }
 
 type EPABreakPoint struct {
-	From, To float64
+	From float64 `json:"from"`
+	To   float64 `json:"to"`
 }
 
 var (
 	epaIAQIs          []EPABreakPoint
 	epaColors         []EpaColor
+	epaCategories     []Category
 	epaConcentrations map[string][]EPABreakPoint
 	epaComputableMaxs map[string]float64
 	epaTruncateRules  map[string]int
 )
 
+type epaStandard struct {
+	beyondIndex BeyondIndexPolicy
+}
+
+// defaultEpa is the EpaStandard of the package level functions, it flags
+// concentrations above the top band
+var defaultEpa = epaStandard{beyondIndex: BeyondIndexFlag}
+
+// EpaStandard is the US EPA AQI standard
+var EpaStandard Standard = defaultEpa
+
+func (epaStandard) Name() string {
+	return "epa"
+}
+
+func (epaStandard) Pollutants() []string {
+	return sortedKeys(epaComputableMaxs)
+}
+
+func (std epaStandard) IAQI(pollutant string, concentration float64) (int, error) {
+	iaqi, interpolated, err := std.iaqi(pollutant, concentration)
+	if !interpolated {
+		return int(iaqi), err
+	}
+	return EpaRoundingPolicy.Index(iaqi), nil
+}
+
+func (epaStandard) AQI(iaqis map[string]int) int {
+	return MaxAggregate(iaqis)
+}
+
+// EpaRoundingPolicy truncates concentrations by the `truncate` struct tags
+// of EpaPollutant and rounds indexes half-up
+var EpaRoundingPolicy RoundingPolicy
+
+func (std epaStandard) FloatIAQI(pollutant string, concentration float64) (float64, error) {
+	iaqi, _, err := std.iaqi(pollutant, concentration)
+	return iaqi, err
+}
+
+func (epaStandard) RoundingPolicy() RoundingPolicy {
+	return EpaRoundingPolicy
+}
+
+func (std epaStandard) BeyondIndexPolicy() BeyondIndexPolicy {
+	return std.beyondIndex
+}
+
+// EpaSanitationPolicy sanitizes concentrations before indexing
+var EpaSanitationPolicy = DefaultSanitationPolicy
+
+func (epaStandard) SanitationPolicy() SanitationPolicy {
+	return EpaSanitationPolicy
+}
+
+func (epaStandard) Unit(pollutant string) (string, error) {
+	if !epaPollutantCalculable(pollutant) {
+		return "", errors.New("Invalid pollutant metric")
+	}
+	return unitOf(epaUnits, pollutant)
+}
+
+func (epaStandard) BreakPoints(pollutant string) ([]Band, error) {
+	return breakPointBands(epaConcentrations, epaIAQIs, pollutant)
+}
+
+func (epaStandard) Categories() []Category {
+	return epaCategories
+}
+
 // initliaze all epa official suggests colors
 func init() {
 	epaColors = make([]EpaColor, 0)
@@ -74,6 +147,31 @@ func init() {
 			},
 		})
 
+	epaCategories = []Category{
+		Category{Level: 1, Name: "Good", From: 0, To: 50,
+			Description: "Air quality is satisfactory, and air pollution poses little or no risk.",
+			Advice:      "None."},
+		Category{Level: 2, Name: "Moderate", From: 51, To: 100,
+			Description: "Air quality is acceptable. However, there may be a risk for some people, particularly those who are unusually sensitive to air pollution.",
+			Advice:      "Unusually sensitive people should consider reducing prolonged or heavy exertion."},
+		Category{Level: 3, Name: "Unhealthy for Sensitive Groups", From: 101, To: 150,
+			Description: "Members of sensitive groups may experience health effects. The general public is less likely to be affected.",
+			Advice:      "Sensitive groups should reduce prolonged or heavy exertion."},
+		Category{Level: 4, Name: "Unhealthy", From: 151, To: 200,
+			Description: "Some members of the general public may experience health effects; members of sensitive groups may experience more serious health effects.",
+			Advice:      "Sensitive groups should avoid prolonged or heavy exertion; everyone else should reduce prolonged or heavy exertion."},
+		Category{Level: 5, Name: "Very Unhealthy", From: 201, To: 300,
+			Description: "Health alert: The risk of health effects is increased for everyone.",
+			Advice:      "Sensitive groups should avoid all physical activity outdoors; everyone else should avoid prolonged or heavy exertion."},
+		Category{Level: 6, Name: "Hazardous", From: 301, To: 500,
+			Description: "Health warning of emergency conditions: everyone is more likely to be affected.",
+			Advice:      "Everyone should avoid all physical activity outdoors."},
+	}
+	for i := range epaCategories {
+		epaCategories[i].ColorName = epaColors[i].Name
+		epaCategories[i].Color = epaColors[i].Color
+	}
+
 	epaIAQIs = []EPABreakPoint{
 		EPABreakPoint{0, 50},
 		EPABreakPoint{51, 100},
@@ -98,9 +196,9 @@ func init() {
 
 	// ? 0.124
 	epaConcentrations["o3_1h"] = []EPABreakPoint{
-		//0, 0, 0.124, 0.164, 0.204, 0.404, 0.504, 0.604
-		EPABreakPoint{0, 0},
-		EPABreakPoint{0, 0},
+		//-, -, 0.124, 0.164, 0.204, 0.404, 0.504, 0.604
+		unreportedBreakPoint,
+		unreportedBreakPoint,
 		EPABreakPoint{0.125, 0.164},
 		EPABreakPoint{0.165, 0.204},
 		EPABreakPoint{0.205, 0.404},
@@ -134,7 +232,7 @@ func init() {
 
 		EPABreakPoint{150.5, 250.4},
 		EPABreakPoint{250.5, 350.4},
-		EPABreakPoint{350.4, 500.4},
+		EPABreakPoint{350.5, 500.4},
 	}
 
 	epaConcentrations["co_8h"] = []EPABreakPoint{
@@ -175,6 +273,9 @@ func init() {
 	}
 
 	epaTruncateRules = GetEPATruncateRules()
+	EpaRoundingPolicy = RoundingPolicy{InputDigits: epaTruncateRules, Output: RoundHalfUp}
+
+	RegisterStandard(EpaStandard)
 }
 
 func epaPollutantCalculable(pollutant string) bool {
@@ -199,39 +300,43 @@ func GetEPATruncateRules() map[string]int {
 	return result
 }
 
-func GetEpaIAQI(pollutant string, concentration float64) (int, error) {
-	if concentration <= 0 {
-		return 0, nil
+// iaqi returns the unrounded IAQI, interpolated is false for the sentinel
+// values of IAQI
+func (std epaStandard) iaqi(pollutant string, concentration float64) (iaqi float64, interpolated bool, err error) {
+	unit, _ := std.Unit(pollutant)
+	if concentration, iaqi, err = EpaSanitationPolicy.apply(concentration, unit); err != nil || concentration == 0 {
+		return iaqi, false, err
 	}
 	if !epaPollutantCalculable(pollutant) {
-		return -1, errors.New("Invalid pollutant metric")
+		return -1, false, errors.New("Invalid pollutant metric")
 	} else {
-		concentration = TruncateFloat(concentration, epaTruncateRules[pollutant])
+		concentration = EpaRoundingPolicy.Input(pollutant, concentration)
 		if concentration > epaComputableMaxs[pollutant] {
 			switch pollutant {
 			case "o3_8h":
-				return -2, errors.New("Concentration value out of range")
+				return -2, false, errors.New("Concentration value out of range")
 			default:
-				return 911, nil
+				return std.beyondIndex.apply(concentration, topBand(std, pollutant))
 			}
 		} else {
-			var bpLow, bpHigh, iaqiLow, iaqiHigh float64
-			for i, point := range epaConcentrations[pollutant] {
-				if concentration >= point.From && concentration <= point.To {
-					bpLow = point.From
-					bpHigh = point.To
-					iaqiLow = epaIAQIs[i].From
-					iaqiHigh = epaIAQIs[i].To
-					break
-				}
-			}
-			if (bpHigh - bpLow) == 0 {
-				return -3, errors.New("Divided by 0???")
+			bands, _ := std.BreakPoints(pollutant)
+			band, v, ok := lookupBand(bands, concentration)
+			if !ok {
+				// below the lowest band
+				return -2, false, errors.New("Concentration value out of range")
 			}
-			return int(Round(((iaqiHigh-iaqiLow)/(bpHigh-bpLow))*(concentration-bpLow)+iaqiLow, 0)), nil
+			return band.interpolate(v)
 		}
 	}
-	return 0, nil
+}
+
+func GetEpaIAQI(pollutant string, concentration float64) (int, error) {
+	return defaultEpa.IAQI(pollutant, concentration)
+}
+
+// GetEpaIAQIFloat returns the IAQI of GetEpaIAQI before rounding
+func GetEpaIAQIFloat(pollutant string, concentration float64) (float64, error) {
+	return defaultEpa.FloatIAQI(pollutant, concentration)
 }
 
 func GetEpaPM25IAQI(concentration float64) (int, error) {
@@ -242,6 +347,9 @@ func GetEpaPM10IAQI(concentration float64) (int, error) {
 	return GetEpaIAQI("pm10_24h", concentration)
 }
 
+// GetAllIAQI indexes the fields by the default BeyondIndexFlag of
+// EpaStandard, pass a standard of WithBeyondIndexPolicy to GetAllIAQI for
+// another policy
 func (epa *EpaPollutant) GetAllIAQI() map[string]int {
 	//// reflect approach
 	var result map[string]int
@@ -283,18 +391,31 @@ func (epa *EpaPollutant) GetAllIAQI() map[string]int {
 	//return result
 }
 
-func (epa *EpaPollutant) GetAQI() int {
-	var result int
-	result = -911
-	allIAQI := epa.GetAllIAQI()
-	for _, v := range allIAQI {
-		if v >= result {
-			result = v
+// GetAllFloatIAQI returns the unrounded IAQIs of GetAllIAQI
+func (epa *EpaPollutant) GetAllFloatIAQI() map[string]float64 {
+	result := make(map[string]float64)
+	for tag, v := range structConcentrations(epa) {
+		if epaPollutantCalculable(tag) {
+			result[tag], _ = GetEpaIAQIFloat(tag, v)
 		}
 	}
 	return result
 }
 
+// GetFloatAQI returns the max unrounded IAQI
+func (epa *EpaPollutant) GetFloatAQI() float64 {
+	result := -1.0
+	for _, v := range epa.GetAllFloatIAQI() {
+		result = math.Max(result, v)
+	}
+	return result
+}
+
+// GetAQI returns the AQI of GetAllIAQI, by the default policy as well
+func (epa *EpaPollutant) GetAQI() int {
+	return EpaStandard.AQI(epa.GetAllIAQI())
+}
+
 func (epa *EpaPollutant) ResponsiblePollutants() []string {
 	var result []string
 	var max int
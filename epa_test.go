@@ -1,6 +1,7 @@
 package aqi
 
 import (
+	"math"
 	"testing"
 )
 
@@ -97,8 +98,8 @@ func TestGetEpaIAQI(t *testing.T) {
 			if err != nil {
 				t.Errorf("%s with max %f + 1(%f) should not raise exception", pollutant, max, overFlow)
 			}
-			if iaqi != 911 {
-				t.Errorf("%s with max %f + 1(%f) should return 911, but return %d", pollutant, max, overFlow, iaqi)
+			if iaqi != BeyondIndex {
+				t.Errorf("%s with max %f + 1(%f) should return BeyondIndex, but return %d", pollutant, max, overFlow, iaqi)
 			}
 		}
 	} // end for
@@ -137,7 +138,7 @@ func TestEpaPollutantGetAllIAQI(t *testing.T) {
 
 	for _, v := range nonZeroPollutants {
 		if result[v] < 0 {
-			t.Errorf("want > 0 actually %f", result[v])
+			t.Errorf("want > 0 actually %d", result[v])
 		}
 	}
 }
@@ -168,3 +169,21 @@ func TestEpaResponsiblePollutants(t *testing.T) {
 		t.Error("should be o3_8h")
 	}
 }
+
+func TestGetEpaIAQIFloat(t *testing.T) {
+	iaqi, err := GetEpaIAQIFloat("pm25_24h", 40.9)
+	if err != nil || math.Abs(iaqi-114.29648) > 1e-5 {
+		t.Errorf("pm25_24h 40.9 float IAQI should be 114.29648, but %v %v", iaqi, err)
+	}
+	if v, _ := GetEpaIAQI("pm25_24h", 40.9); v != int(Round(iaqi, 0)) {
+		t.Errorf("pm25_24h 40.9 IAQI should be the rounded float IAQI, but %d", v)
+	}
+	if _, err = GetEpaIAQIFloat("foo", 1); err == nil {
+		t.Error("fake foo pollutant should fail with error")
+	}
+
+	epa := &EpaPollutant{PM25Pollutant24H: 40.9, O3Pollutant8H: 0.08742}
+	if aqi := epa.GetFloatAQI(); int(Round(aqi, 0)) != epa.GetAQI() || aqi == float64(epa.GetAQI()) {
+		t.Errorf("float AQI should be the unrounded %d, but %v", epa.GetAQI(), aqi)
+	}
+}
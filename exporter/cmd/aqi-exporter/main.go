@@ -0,0 +1,41 @@
+// Command aqi-exporter serves Prometheus metrics of station observations
+// pushed to /observations or appended to a file, addr defaults to :9101.
+//
+//	aqi-exporter [addr] [file]
+//	aqi-exporter :9101 observations.jsonl
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/elvuel/go-aqi/exporter"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "aqi-exporter:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	addr := ":9101"
+	if len(args) > 0 {
+		addr = args[0]
+	}
+	e := exporter.New()
+	errc := make(chan error, 2)
+	if len(args) > 1 {
+		go func() {
+			errc <- exporter.TailFile(context.Background(), args[1], e, exporter.DefaultTailInterval)
+		}()
+	}
+	go func() {
+		errc <- http.ListenAndServe(addr, exporter.NewHandler(e))
+	}()
+	fmt.Fprintln(os.Stderr, "aqi-exporter: exporting on", addr)
+	return <-errc
+}
@@ -0,0 +1,168 @@
+// Package exporter exposes the AQI of the latest station observations as
+// Prometheus metrics
+package exporter
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	aqi "github.com/elvuel/go-aqi"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "aqi"
+
+// Observation is a set of concentrations measured by a station at a time
+type Observation struct {
+	Station        string             `json:"station"`
+	Standard       string             `json:"standard"`
+	Time           time.Time          `json:"time"`
+	Concentrations map[string]float64 `json:"concentrations"`
+}
+
+var (
+	concentrationDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "concentration"),
+		"Latest concentration of a pollutant, in the unit of the standard.",
+		[]string{"station", "standard", "pollutant"}, nil)
+	iaqiDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "iaqi"),
+		"Latest individual index of a pollutant.",
+		[]string{"station", "standard", "pollutant"}, nil)
+	indexDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "index"),
+		"Latest composite index, responsible pollutants are comma separated.",
+		[]string{"station", "standard", "responsible_pollutant"}, nil)
+	categoryDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "category_level"),
+		"Category level of the latest composite index.",
+		[]string{"station", "standard"}, nil)
+	timestampDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "observation_timestamp_seconds"),
+		"Time of the latest observation.",
+		[]string{"station", "standard"}, nil)
+)
+
+type key struct {
+	station, standard string
+}
+
+type entry struct {
+	time   time.Time
+	result aqi.Result
+	// level is the category level, -1 for standards without categories
+	level int
+}
+
+// Exporter keeps the result of the latest observation of every station and
+// standard, it is a prometheus.Collector safe for concurrent use
+type Exporter struct {
+	mu     sync.RWMutex
+	latest map[key]entry
+	errors prometheus.Counter
+}
+
+func New() *Exporter {
+	return &Exporter{
+		latest: make(map[key]entry),
+		errors: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "exporter",
+			Name:      "errors_total",
+			Help:      "Observations which could not be read or calculated.",
+		}),
+	}
+}
+
+// Observe calculates an observation, an observation older than the latest
+// one of its station and standard is ignored
+func (e *Exporter) Observe(observation Observation) error {
+	err := e.observe(observation)
+	if err != nil {
+		e.errors.Inc()
+	}
+	return err
+}
+
+func (e *Exporter) observe(observation Observation) error {
+	if observation.Station == "" {
+		return errors.New("Missing station")
+	}
+	std, err := aqi.GetStandard(observation.Standard)
+	if err != nil {
+		return err
+	}
+	if len(observation.Concentrations) == 0 {
+		return errors.New("No concentration")
+	}
+	result, err := aqi.Calculate(std, observation.Concentrations)
+	if err != nil {
+		return errors.New(observation.Station + ": " + err.Error())
+	}
+
+	level := -1
+	if category, err := aqi.GetCategory(std, result.AQI); err == nil {
+		level = category.Level
+	}
+
+	k := key{observation.Station, std.Name()}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if latest, ok := e.latest[k]; ok && observation.Time.Before(latest.time) {
+		return nil
+	}
+	e.latest[k] = entry{observation.Time, result, level}
+	return nil
+}
+
+// fail counts an observation which could not be read
+func (e *Exporter) fail() {
+	e.errors.Inc()
+}
+
+func (e *Exporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- concentrationDesc
+	ch <- iaqiDesc
+	ch <- indexDesc
+	ch <- categoryDesc
+	ch <- timestampDesc
+	e.errors.Describe(ch)
+}
+
+func (e *Exporter) Collect(ch chan<- prometheus.Metric) {
+	e.mu.RLock()
+	keys := make([]key, 0, len(e.latest))
+	for k := range e.latest {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].station != keys[j].station {
+			return keys[i].station < keys[j].station
+		}
+		return keys[i].standard < keys[j].standard
+	})
+	for _, k := range keys {
+		latest := e.latest[k]
+		result := latest.result
+		for pollutant, v := range result.Concentrations {
+			ch <- prometheus.MustNewConstMetric(concentrationDesc, prometheus.GaugeValue, v, k.station, k.standard, pollutant)
+		}
+		for pollutant, v := range result.IAQIs {
+			ch <- prometheus.MustNewConstMetric(iaqiDesc, prometheus.GaugeValue, float64(v), k.station, k.standard, pollutant)
+		}
+		ch <- prometheus.MustNewConstMetric(indexDesc, prometheus.GaugeValue, float64(result.AQI),
+			k.station, k.standard, strings.Join(result.ResponsiblePollutants, ","))
+		if latest.level >= 0 {
+			ch <- prometheus.MustNewConstMetric(categoryDesc, prometheus.GaugeValue, float64(latest.level), k.station, k.standard)
+		}
+		if !latest.time.IsZero() {
+			ch <- prometheus.MustNewConstMetric(timestampDesc, prometheus.GaugeValue,
+				float64(latest.time.UnixNano())/1e9, k.station, k.standard)
+		}
+	}
+	e.mu.RUnlock()
+	e.errors.Collect(ch)
+}
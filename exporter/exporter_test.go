@@ -0,0 +1,72 @@
+package exporter
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestExporterCollect(t *testing.T) {
+	e := New()
+	now := time.Date(2013, 1, 15, 8, 0, 0, 0, time.UTC)
+	err := e.Observe(Observation{Station: "1001", Standard: "mep", Time: now,
+		Concentrations: map[string]float64{"pm25_24h": 82, "pm10_24h": 113}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// an older observation should not replace the latest one
+	err = e.Observe(Observation{Station: "1001", Standard: "mep", Time: now.Add(-time.Hour),
+		Concentrations: map[string]float64{"pm25_24h": 10}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `
+# HELP aqi_category_level Category level of the latest composite index.
+# TYPE aqi_category_level gauge
+aqi_category_level{standard="mep",station="1001"} 3
+# HELP aqi_concentration Latest concentration of a pollutant, in the unit of the standard.
+# TYPE aqi_concentration gauge
+aqi_concentration{pollutant="pm10_24h",standard="mep",station="1001"} 113
+aqi_concentration{pollutant="pm25_24h",standard="mep",station="1001"} 82
+# HELP aqi_iaqi Latest individual index of a pollutant.
+# TYPE aqi_iaqi gauge
+aqi_iaqi{pollutant="pm10_24h",standard="mep",station="1001"} 82
+aqi_iaqi{pollutant="pm25_24h",standard="mep",station="1001"} 109
+# HELP aqi_index Latest composite index, responsible pollutants are comma separated.
+# TYPE aqi_index gauge
+aqi_index{responsible_pollutant="pm25_24h",standard="mep",station="1001"} 109
+# HELP aqi_observation_timestamp_seconds Time of the latest observation.
+# TYPE aqi_observation_timestamp_seconds gauge
+aqi_observation_timestamp_seconds{standard="mep",station="1001"} 1.3582368e+09
+# HELP aqi_exporter_errors_total Observations which could not be read or calculated.
+# TYPE aqi_exporter_errors_total counter
+aqi_exporter_errors_total 0
+`
+	if err = testutil.CollectAndCompare(e, strings.NewReader(expected)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestExporterObserveErrors(t *testing.T) {
+	e := New()
+	seeds := []Observation{
+		{Standard: "mep", Concentrations: map[string]float64{"pm25_24h": 82}},
+		{Station: "1001", Standard: "foo", Concentrations: map[string]float64{"pm25_24h": 82}},
+		{Station: "1001", Standard: "mep"},
+		{Station: "1001", Standard: "mep", Concentrations: map[string]float64{"foo": 82}},
+	}
+	for _, seed := range seeds {
+		if err := e.Observe(seed); err == nil {
+			t.Errorf("%v should fail with error", seed)
+		}
+	}
+	if v := testutil.ToFloat64(e.errors); v != float64(len(seeds)) {
+		t.Errorf("errors should be %d, but %v", len(seeds), v)
+	}
+	if n := testutil.CollectAndCount(e, "aqi_index"); n != 0 {
+		t.Errorf("invalid observations should not be exported, but %d", n)
+	}
+}
@@ -0,0 +1,153 @@
+package exporter
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// maxBodyBytes limits pushed request bodies
+const maxBodyBytes = 1 << 20
+
+// DefaultTailInterval is the polling interval of TailFile
+const DefaultTailInterval = time.Second
+
+// NewHandler serves the metrics of e at /metrics and accepts pushed
+// observations at /observations
+func NewHandler(e *Exporter) http.Handler {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(e)
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	mux.Handle("/observations", PushHandler(e))
+	return mux
+}
+
+// PushHandler accepts POST requests of an observation or an array of
+// observations in JSON. Every valid observation is kept, the errors of the
+// others are answered with 400
+func PushHandler(e *Exporter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			w.Header().Set("Allow", "POST")
+			writeError(w, http.StatusMethodNotAllowed, errors.New("Method "+r.Method+" not allowed"))
+			return
+		}
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		var observations []Observation
+		body = bytes.TrimSpace(body)
+		if len(body) > 0 && body[0] == '[' {
+			err = json.Unmarshal(body, &observations)
+		} else {
+			observations = make([]Observation, 1)
+			err = json.Unmarshal(body, &observations[0])
+		}
+		if err != nil {
+			e.fail()
+			writeError(w, http.StatusBadRequest, errors.New("Invalid JSON body: "+err.Error()))
+			return
+		}
+		var messages []string
+		for _, observation := range observations {
+			if err = e.Observe(observation); err != nil {
+				messages = append(messages, err.Error())
+			}
+		}
+		if len(messages) > 0 {
+			writeError(w, http.StatusBadRequest, errors.New(strings.Join(messages, "; ")))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(struct {
+		Error string `json:"error"`
+	}{err.Error()})
+}
+
+// TailFile reads observations, one JSON object per line, from the file at
+// path and follows the lines appended to it every interval until ctx is
+// done. A truncated file is read again from the beginning, invalid lines are
+// counted as errors and skipped
+func TailFile(ctx context.Context, path string, e *Exporter, interval time.Duration) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if interval <= 0 {
+		interval = DefaultTailInterval
+	}
+
+	reader := bufio.NewReader(file)
+	var offset int64
+	var partial []byte
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		for {
+			line, err := reader.ReadBytes('\n')
+			offset += int64(len(line))
+			if err != nil {
+				// keep an incomplete last line until it is terminated
+				partial = append(partial, line...)
+				if err != io.EOF {
+					return err
+				}
+				break
+			}
+			e.observeLine(append(partial, line...))
+			partial = nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		info, err := file.Stat()
+		if err != nil {
+			return err
+		}
+		if info.Size() < offset {
+			if _, err = file.Seek(0, io.SeekStart); err != nil {
+				return err
+			}
+			reader.Reset(file)
+			offset = 0
+			partial = nil
+		}
+	}
+}
+
+func (e *Exporter) observeLine(line []byte) {
+	line = bytes.TrimSpace(line)
+	if len(line) == 0 {
+		return
+	}
+	var observation Observation
+	if err := json.Unmarshal(line, &observation); err != nil {
+		e.fail()
+		return
+	}
+	e.Observe(observation)
+}
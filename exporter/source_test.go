@@ -0,0 +1,99 @@
+package exporter
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestPushHandler(t *testing.T) {
+	e := New()
+	ts := httptest.NewServer(NewHandler(e))
+	defer ts.Close()
+
+	seeds := []struct {
+		Body   string
+		Status int
+	}{
+		{`{"station": "1001", "standard": "mep", "concentrations": {"pm25_24h": 82}}`, http.StatusNoContent},
+		{`[{"station": "1002", "standard": "epa", "concentrations": {"pm25_24h": 40.9}},
+		   {"station": "1003", "standard": "foo", "concentrations": {"pm25_24h": 40.9}}]`, http.StatusBadRequest},
+		{`{"station":`, http.StatusBadRequest},
+	}
+	for _, seed := range seeds {
+		resp, err := http.Post(ts.URL+"/observations", "application/json", strings.NewReader(seed.Body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != seed.Status {
+			t.Errorf("%s should be %d, but %d", seed.Body, seed.Status, resp.StatusCode)
+		}
+	}
+	if n := testutil.CollectAndCount(e, "aqi_index"); n != 2 {
+		t.Errorf("valid observations of 2 stations should be exported, but %d", n)
+	}
+
+	resp, err := http.Get(ts.URL + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if !strings.Contains(string(body), `aqi_index{responsible_pollutant="pm25_24h",standard="epa",station="1002"} 114`) {
+		t.Errorf("metrics should contain the index of station 1002, but\n%s", body)
+	}
+}
+
+func TestTailFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "observations.jsonl")
+	content := `{"station": "1001", "standard": "mep", "concentrations": {"pm25_24h": 82}}
+invalid
+{"station": "1002", "standard": "mep", "concentrations": {"pm25_24h": 35}}
+{"station": "1003", "standard": "mep", "concen`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	e := New()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- TailFile(ctx, path, e, 10*time.Millisecond)
+	}()
+
+	waitFor := func(n int) {
+		for i := 0; i < 100 && testutil.CollectAndCount(e, "aqi_index") != n; i++ {
+			time.Sleep(10 * time.Millisecond)
+		}
+		if count := testutil.CollectAndCount(e, "aqi_index"); count != n {
+			t.Errorf("%d stations should be exported, but %d", n, count)
+		}
+	}
+	waitFor(2)
+
+	// complete the last line
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.WriteString(`trations": {"pm25_24h": 35}}` + "\n")
+	file.Close()
+	waitFor(3)
+
+	cancel()
+	if err = <-done; err != context.Canceled {
+		t.Errorf("TailFile should stop with context.Canceled, but %v", err)
+	}
+	if v := testutil.ToFloat64(e.errors); v != 1 {
+		t.Errorf("errors should be 1, but %v", v)
+	}
+}
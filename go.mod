@@ -0,0 +1,3 @@
+module github.com/elvuel/go-aqi
+
+go 1.25.0
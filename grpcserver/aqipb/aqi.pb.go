@@ -0,0 +1,785 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: aqi/v1/aqi.proto
+
+// Package aqi.v1 calculates air quality indexes, pollutants are keyed by the
+// same tags as the json tags of the pollutant structs, e.g. "pm25_24h".
+
+package aqipb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Category struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Level     int32                  `protobuf:"varint,1,opt,name=level,proto3" json:"level,omitempty"`
+	Name      string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	From      int32                  `protobuf:"varint,3,opt,name=from,proto3" json:"from,omitempty"`
+	To        int32                  `protobuf:"varint,4,opt,name=to,proto3" json:"to,omitempty"`
+	ColorName string                 `protobuf:"bytes,5,opt,name=color_name,json=colorName,proto3" json:"color_name,omitempty"`
+	// hex RGB, e.g. "#00E400"
+	Color         string `protobuf:"bytes,6,opt,name=color,proto3" json:"color,omitempty"`
+	Description   string `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
+	Advice        string `protobuf:"bytes,8,opt,name=advice,proto3" json:"advice,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Category) Reset() {
+	*x = Category{}
+	mi := &file_aqi_v1_aqi_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Category) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Category) ProtoMessage() {}
+
+func (x *Category) ProtoReflect() protoreflect.Message {
+	mi := &file_aqi_v1_aqi_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Category.ProtoReflect.Descriptor instead.
+func (*Category) Descriptor() ([]byte, []int) {
+	return file_aqi_v1_aqi_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Category) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *Category) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Category) GetFrom() int32 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *Category) GetTo() int32 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *Category) GetColorName() string {
+	if x != nil {
+		return x.ColorName
+	}
+	return ""
+}
+
+func (x *Category) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+func (x *Category) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Category) GetAdvice() string {
+	if x != nil {
+		return x.Advice
+	}
+	return ""
+}
+
+type Standard struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Pollutants    []string               `protobuf:"bytes,2,rep,name=pollutants,proto3" json:"pollutants,omitempty"`
+	Categories    []*Category            `protobuf:"bytes,3,rep,name=categories,proto3" json:"categories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Standard) Reset() {
+	*x = Standard{}
+	mi := &file_aqi_v1_aqi_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Standard) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Standard) ProtoMessage() {}
+
+func (x *Standard) ProtoReflect() protoreflect.Message {
+	mi := &file_aqi_v1_aqi_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Standard.ProtoReflect.Descriptor instead.
+func (*Standard) Descriptor() ([]byte, []int) {
+	return file_aqi_v1_aqi_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Standard) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Standard) GetPollutants() []string {
+	if x != nil {
+		return x.Pollutants
+	}
+	return nil
+}
+
+func (x *Standard) GetCategories() []*Category {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+type ListStandardsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStandardsRequest) Reset() {
+	*x = ListStandardsRequest{}
+	mi := &file_aqi_v1_aqi_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStandardsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStandardsRequest) ProtoMessage() {}
+
+func (x *ListStandardsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aqi_v1_aqi_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStandardsRequest.ProtoReflect.Descriptor instead.
+func (*ListStandardsRequest) Descriptor() ([]byte, []int) {
+	return file_aqi_v1_aqi_proto_rawDescGZIP(), []int{2}
+}
+
+type ListStandardsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Standards     []*Standard            `protobuf:"bytes,1,rep,name=standards,proto3" json:"standards,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStandardsResponse) Reset() {
+	*x = ListStandardsResponse{}
+	mi := &file_aqi_v1_aqi_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStandardsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStandardsResponse) ProtoMessage() {}
+
+func (x *ListStandardsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aqi_v1_aqi_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStandardsResponse.ProtoReflect.Descriptor instead.
+func (*ListStandardsResponse) Descriptor() ([]byte, []int) {
+	return file_aqi_v1_aqi_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListStandardsResponse) GetStandards() []*Standard {
+	if x != nil {
+		return x.Standards
+	}
+	return nil
+}
+
+type GetIAQIRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Standard      string                 `protobuf:"bytes,1,opt,name=standard,proto3" json:"standard,omitempty"`
+	Pollutant     string                 `protobuf:"bytes,2,opt,name=pollutant,proto3" json:"pollutant,omitempty"`
+	Concentration float64                `protobuf:"fixed64,3,opt,name=concentration,proto3" json:"concentration,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetIAQIRequest) Reset() {
+	*x = GetIAQIRequest{}
+	mi := &file_aqi_v1_aqi_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetIAQIRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetIAQIRequest) ProtoMessage() {}
+
+func (x *GetIAQIRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aqi_v1_aqi_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetIAQIRequest.ProtoReflect.Descriptor instead.
+func (*GetIAQIRequest) Descriptor() ([]byte, []int) {
+	return file_aqi_v1_aqi_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetIAQIRequest) GetStandard() string {
+	if x != nil {
+		return x.Standard
+	}
+	return ""
+}
+
+func (x *GetIAQIRequest) GetPollutant() string {
+	if x != nil {
+		return x.Pollutant
+	}
+	return ""
+}
+
+func (x *GetIAQIRequest) GetConcentration() float64 {
+	if x != nil {
+		return x.Concentration
+	}
+	return 0
+}
+
+type GetIAQIResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Standard      string                 `protobuf:"bytes,1,opt,name=standard,proto3" json:"standard,omitempty"`
+	Pollutant     string                 `protobuf:"bytes,2,opt,name=pollutant,proto3" json:"pollutant,omitempty"`
+	Concentration float64                `protobuf:"fixed64,3,opt,name=concentration,proto3" json:"concentration,omitempty"`
+	Iaqi          int32                  `protobuf:"varint,4,opt,name=iaqi,proto3" json:"iaqi,omitempty"`
+	Category      *Category              `protobuf:"bytes,5,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetIAQIResponse) Reset() {
+	*x = GetIAQIResponse{}
+	mi := &file_aqi_v1_aqi_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetIAQIResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetIAQIResponse) ProtoMessage() {}
+
+func (x *GetIAQIResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aqi_v1_aqi_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetIAQIResponse.ProtoReflect.Descriptor instead.
+func (*GetIAQIResponse) Descriptor() ([]byte, []int) {
+	return file_aqi_v1_aqi_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetIAQIResponse) GetStandard() string {
+	if x != nil {
+		return x.Standard
+	}
+	return ""
+}
+
+func (x *GetIAQIResponse) GetPollutant() string {
+	if x != nil {
+		return x.Pollutant
+	}
+	return ""
+}
+
+func (x *GetIAQIResponse) GetConcentration() float64 {
+	if x != nil {
+		return x.Concentration
+	}
+	return 0
+}
+
+func (x *GetIAQIResponse) GetIaqi() int32 {
+	if x != nil {
+		return x.Iaqi
+	}
+	return 0
+}
+
+func (x *GetIAQIResponse) GetCategory() *Category {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
+type GetAQIRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Standard       string                 `protobuf:"bytes,1,opt,name=standard,proto3" json:"standard,omitempty"`
+	Concentrations map[string]float64     `protobuf:"bytes,2,rep,name=concentrations,proto3" json:"concentrations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetAQIRequest) Reset() {
+	*x = GetAQIRequest{}
+	mi := &file_aqi_v1_aqi_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAQIRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAQIRequest) ProtoMessage() {}
+
+func (x *GetAQIRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aqi_v1_aqi_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAQIRequest.ProtoReflect.Descriptor instead.
+func (*GetAQIRequest) Descriptor() ([]byte, []int) {
+	return file_aqi_v1_aqi_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetAQIRequest) GetStandard() string {
+	if x != nil {
+		return x.Standard
+	}
+	return ""
+}
+
+func (x *GetAQIRequest) GetConcentrations() map[string]float64 {
+	if x != nil {
+		return x.Concentrations
+	}
+	return nil
+}
+
+type Observation struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Station        string                 `protobuf:"bytes,1,opt,name=station,proto3" json:"station,omitempty"`
+	Standard       string                 `protobuf:"bytes,2,opt,name=standard,proto3" json:"standard,omitempty"`
+	Time           *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=time,proto3" json:"time,omitempty"`
+	Concentrations map[string]float64     `protobuf:"bytes,4,rep,name=concentrations,proto3" json:"concentrations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Observation) Reset() {
+	*x = Observation{}
+	mi := &file_aqi_v1_aqi_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Observation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Observation) ProtoMessage() {}
+
+func (x *Observation) ProtoReflect() protoreflect.Message {
+	mi := &file_aqi_v1_aqi_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Observation.ProtoReflect.Descriptor instead.
+func (*Observation) Descriptor() ([]byte, []int) {
+	return file_aqi_v1_aqi_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Observation) GetStation() string {
+	if x != nil {
+		return x.Station
+	}
+	return ""
+}
+
+func (x *Observation) GetStandard() string {
+	if x != nil {
+		return x.Standard
+	}
+	return ""
+}
+
+func (x *Observation) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *Observation) GetConcentrations() map[string]float64 {
+	if x != nil {
+		return x.Concentrations
+	}
+	return nil
+}
+
+type AQIResult struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Station               string                 `protobuf:"bytes,1,opt,name=station,proto3" json:"station,omitempty"`
+	Standard              string                 `protobuf:"bytes,2,opt,name=standard,proto3" json:"standard,omitempty"`
+	Time                  *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=time,proto3" json:"time,omitempty"`
+	Concentrations        map[string]float64     `protobuf:"bytes,4,rep,name=concentrations,proto3" json:"concentrations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	Iaqis                 map[string]int32       `protobuf:"bytes,5,rep,name=iaqis,proto3" json:"iaqis,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	Aqi                   int32                  `protobuf:"varint,6,opt,name=aqi,proto3" json:"aqi,omitempty"`
+	ResponsiblePollutants []string               `protobuf:"bytes,7,rep,name=responsible_pollutants,json=responsiblePollutants,proto3" json:"responsible_pollutants,omitempty"`
+	Category              *Category              `protobuf:"bytes,8,opt,name=category,proto3" json:"category,omitempty"`
+	// set when the observation fails to calculate
+	Error         string `protobuf:"bytes,9,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AQIResult) Reset() {
+	*x = AQIResult{}
+	mi := &file_aqi_v1_aqi_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AQIResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AQIResult) ProtoMessage() {}
+
+func (x *AQIResult) ProtoReflect() protoreflect.Message {
+	mi := &file_aqi_v1_aqi_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AQIResult.ProtoReflect.Descriptor instead.
+func (*AQIResult) Descriptor() ([]byte, []int) {
+	return file_aqi_v1_aqi_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *AQIResult) GetStation() string {
+	if x != nil {
+		return x.Station
+	}
+	return ""
+}
+
+func (x *AQIResult) GetStandard() string {
+	if x != nil {
+		return x.Standard
+	}
+	return ""
+}
+
+func (x *AQIResult) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *AQIResult) GetConcentrations() map[string]float64 {
+	if x != nil {
+		return x.Concentrations
+	}
+	return nil
+}
+
+func (x *AQIResult) GetIaqis() map[string]int32 {
+	if x != nil {
+		return x.Iaqis
+	}
+	return nil
+}
+
+func (x *AQIResult) GetAqi() int32 {
+	if x != nil {
+		return x.Aqi
+	}
+	return 0
+}
+
+func (x *AQIResult) GetResponsiblePollutants() []string {
+	if x != nil {
+		return x.ResponsiblePollutants
+	}
+	return nil
+}
+
+func (x *AQIResult) GetCategory() *Category {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
+func (x *AQIResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+var File_aqi_v1_aqi_proto protoreflect.FileDescriptor
+
+const file_aqi_v1_aqi_proto_rawDesc = "" +
+	"\n" +
+	"\x10aqi/v1/aqi.proto\x12\x06aqi.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc7\x01\n" +
+	"\bCategory\x12\x14\n" +
+	"\x05level\x18\x01 \x01(\x05R\x05level\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04from\x18\x03 \x01(\x05R\x04from\x12\x0e\n" +
+	"\x02to\x18\x04 \x01(\x05R\x02to\x12\x1d\n" +
+	"\n" +
+	"color_name\x18\x05 \x01(\tR\tcolorName\x12\x14\n" +
+	"\x05color\x18\x06 \x01(\tR\x05color\x12 \n" +
+	"\vdescription\x18\a \x01(\tR\vdescription\x12\x16\n" +
+	"\x06advice\x18\b \x01(\tR\x06advice\"p\n" +
+	"\bStandard\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1e\n" +
+	"\n" +
+	"pollutants\x18\x02 \x03(\tR\n" +
+	"pollutants\x120\n" +
+	"\n" +
+	"categories\x18\x03 \x03(\v2\x10.aqi.v1.CategoryR\n" +
+	"categories\"\x16\n" +
+	"\x14ListStandardsRequest\"G\n" +
+	"\x15ListStandardsResponse\x12.\n" +
+	"\tstandards\x18\x01 \x03(\v2\x10.aqi.v1.StandardR\tstandards\"p\n" +
+	"\x0eGetIAQIRequest\x12\x1a\n" +
+	"\bstandard\x18\x01 \x01(\tR\bstandard\x12\x1c\n" +
+	"\tpollutant\x18\x02 \x01(\tR\tpollutant\x12$\n" +
+	"\rconcentration\x18\x03 \x01(\x01R\rconcentration\"\xb3\x01\n" +
+	"\x0fGetIAQIResponse\x12\x1a\n" +
+	"\bstandard\x18\x01 \x01(\tR\bstandard\x12\x1c\n" +
+	"\tpollutant\x18\x02 \x01(\tR\tpollutant\x12$\n" +
+	"\rconcentration\x18\x03 \x01(\x01R\rconcentration\x12\x12\n" +
+	"\x04iaqi\x18\x04 \x01(\x05R\x04iaqi\x12,\n" +
+	"\bcategory\x18\x05 \x01(\v2\x10.aqi.v1.CategoryR\bcategory\"\xc1\x01\n" +
+	"\rGetAQIRequest\x12\x1a\n" +
+	"\bstandard\x18\x01 \x01(\tR\bstandard\x12Q\n" +
+	"\x0econcentrations\x18\x02 \x03(\v2).aqi.v1.GetAQIRequest.ConcentrationsEntryR\x0econcentrations\x1aA\n" +
+	"\x13ConcentrationsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"\x87\x02\n" +
+	"\vObservation\x12\x18\n" +
+	"\astation\x18\x01 \x01(\tR\astation\x12\x1a\n" +
+	"\bstandard\x18\x02 \x01(\tR\bstandard\x12.\n" +
+	"\x04time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12O\n" +
+	"\x0econcentrations\x18\x04 \x03(\v2'.aqi.v1.Observation.ConcentrationsEntryR\x0econcentrations\x1aA\n" +
+	"\x13ConcentrationsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"\xfe\x03\n" +
+	"\tAQIResult\x12\x18\n" +
+	"\astation\x18\x01 \x01(\tR\astation\x12\x1a\n" +
+	"\bstandard\x18\x02 \x01(\tR\bstandard\x12.\n" +
+	"\x04time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12M\n" +
+	"\x0econcentrations\x18\x04 \x03(\v2%.aqi.v1.AQIResult.ConcentrationsEntryR\x0econcentrations\x122\n" +
+	"\x05iaqis\x18\x05 \x03(\v2\x1c.aqi.v1.AQIResult.IaqisEntryR\x05iaqis\x12\x10\n" +
+	"\x03aqi\x18\x06 \x01(\x05R\x03aqi\x125\n" +
+	"\x16responsible_pollutants\x18\a \x03(\tR\x15responsiblePollutants\x12,\n" +
+	"\bcategory\x18\b \x01(\v2\x10.aqi.v1.CategoryR\bcategory\x12\x14\n" +
+	"\x05error\x18\t \x01(\tR\x05error\x1aA\n" +
+	"\x13ConcentrationsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\x1a8\n" +
+	"\n" +
+	"IaqisEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x012\x83\x02\n" +
+	"\n" +
+	"AQIService\x12L\n" +
+	"\rListStandards\x12\x1c.aqi.v1.ListStandardsRequest\x1a\x1d.aqi.v1.ListStandardsResponse\x12:\n" +
+	"\aGetIAQI\x12\x16.aqi.v1.GetIAQIRequest\x1a\x17.aqi.v1.GetIAQIResponse\x122\n" +
+	"\x06GetAQI\x12\x15.aqi.v1.GetAQIRequest\x1a\x11.aqi.v1.AQIResult\x127\n" +
+	"\tStreamAQI\x12\x13.aqi.v1.Observation\x1a\x11.aqi.v1.AQIResult(\x010\x01B+Z)github.com/elvuel/go-aqi/grpcserver/aqipbb\x06proto3"
+
+var (
+	file_aqi_v1_aqi_proto_rawDescOnce sync.Once
+	file_aqi_v1_aqi_proto_rawDescData []byte
+)
+
+func file_aqi_v1_aqi_proto_rawDescGZIP() []byte {
+	file_aqi_v1_aqi_proto_rawDescOnce.Do(func() {
+		file_aqi_v1_aqi_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_aqi_v1_aqi_proto_rawDesc), len(file_aqi_v1_aqi_proto_rawDesc)))
+	})
+	return file_aqi_v1_aqi_proto_rawDescData
+}
+
+var file_aqi_v1_aqi_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_aqi_v1_aqi_proto_goTypes = []any{
+	(*Category)(nil),              // 0: aqi.v1.Category
+	(*Standard)(nil),              // 1: aqi.v1.Standard
+	(*ListStandardsRequest)(nil),  // 2: aqi.v1.ListStandardsRequest
+	(*ListStandardsResponse)(nil), // 3: aqi.v1.ListStandardsResponse
+	(*GetIAQIRequest)(nil),        // 4: aqi.v1.GetIAQIRequest
+	(*GetIAQIResponse)(nil),       // 5: aqi.v1.GetIAQIResponse
+	(*GetAQIRequest)(nil),         // 6: aqi.v1.GetAQIRequest
+	(*Observation)(nil),           // 7: aqi.v1.Observation
+	(*AQIResult)(nil),             // 8: aqi.v1.AQIResult
+	nil,                           // 9: aqi.v1.GetAQIRequest.ConcentrationsEntry
+	nil,                           // 10: aqi.v1.Observation.ConcentrationsEntry
+	nil,                           // 11: aqi.v1.AQIResult.ConcentrationsEntry
+	nil,                           // 12: aqi.v1.AQIResult.IaqisEntry
+	(*timestamppb.Timestamp)(nil), // 13: google.protobuf.Timestamp
+}
+var file_aqi_v1_aqi_proto_depIdxs = []int32{
+	0,  // 0: aqi.v1.Standard.categories:type_name -> aqi.v1.Category
+	1,  // 1: aqi.v1.ListStandardsResponse.standards:type_name -> aqi.v1.Standard
+	0,  // 2: aqi.v1.GetIAQIResponse.category:type_name -> aqi.v1.Category
+	9,  // 3: aqi.v1.GetAQIRequest.concentrations:type_name -> aqi.v1.GetAQIRequest.ConcentrationsEntry
+	13, // 4: aqi.v1.Observation.time:type_name -> google.protobuf.Timestamp
+	10, // 5: aqi.v1.Observation.concentrations:type_name -> aqi.v1.Observation.ConcentrationsEntry
+	13, // 6: aqi.v1.AQIResult.time:type_name -> google.protobuf.Timestamp
+	11, // 7: aqi.v1.AQIResult.concentrations:type_name -> aqi.v1.AQIResult.ConcentrationsEntry
+	12, // 8: aqi.v1.AQIResult.iaqis:type_name -> aqi.v1.AQIResult.IaqisEntry
+	0,  // 9: aqi.v1.AQIResult.category:type_name -> aqi.v1.Category
+	2,  // 10: aqi.v1.AQIService.ListStandards:input_type -> aqi.v1.ListStandardsRequest
+	4,  // 11: aqi.v1.AQIService.GetIAQI:input_type -> aqi.v1.GetIAQIRequest
+	6,  // 12: aqi.v1.AQIService.GetAQI:input_type -> aqi.v1.GetAQIRequest
+	7,  // 13: aqi.v1.AQIService.StreamAQI:input_type -> aqi.v1.Observation
+	3,  // 14: aqi.v1.AQIService.ListStandards:output_type -> aqi.v1.ListStandardsResponse
+	5,  // 15: aqi.v1.AQIService.GetIAQI:output_type -> aqi.v1.GetIAQIResponse
+	8,  // 16: aqi.v1.AQIService.GetAQI:output_type -> aqi.v1.AQIResult
+	8,  // 17: aqi.v1.AQIService.StreamAQI:output_type -> aqi.v1.AQIResult
+	14, // [14:18] is the sub-list for method output_type
+	10, // [10:14] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_aqi_v1_aqi_proto_init() }
+func file_aqi_v1_aqi_proto_init() {
+	if File_aqi_v1_aqi_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_aqi_v1_aqi_proto_rawDesc), len(file_aqi_v1_aqi_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_aqi_v1_aqi_proto_goTypes,
+		DependencyIndexes: file_aqi_v1_aqi_proto_depIdxs,
+		MessageInfos:      file_aqi_v1_aqi_proto_msgTypes,
+	}.Build()
+	File_aqi_v1_aqi_proto = out.File
+	file_aqi_v1_aqi_proto_goTypes = nil
+	file_aqi_v1_aqi_proto_depIdxs = nil
+}
@@ -0,0 +1,243 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: aqi/v1/aqi.proto
+
+// Package aqi.v1 calculates air quality indexes, pollutants are keyed by the
+// same tags as the json tags of the pollutant structs, e.g. "pm25_24h".
+
+package aqipb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AQIService_ListStandards_FullMethodName = "/aqi.v1.AQIService/ListStandards"
+	AQIService_GetIAQI_FullMethodName       = "/aqi.v1.AQIService/GetIAQI"
+	AQIService_GetAQI_FullMethodName        = "/aqi.v1.AQIService/GetAQI"
+	AQIService_StreamAQI_FullMethodName     = "/aqi.v1.AQIService/StreamAQI"
+)
+
+// AQIServiceClient is the client API for AQIService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AQIServiceClient interface {
+	// ListStandards returns all registered standards.
+	ListStandards(ctx context.Context, in *ListStandardsRequest, opts ...grpc.CallOption) (*ListStandardsResponse, error)
+	// GetIAQI returns the individual index of a pollutant.
+	GetIAQI(ctx context.Context, in *GetIAQIRequest, opts ...grpc.CallOption) (*GetIAQIResponse, error)
+	// GetAQI returns the composite index of concentrations.
+	GetAQI(ctx context.Context, in *GetAQIRequest, opts ...grpc.CallOption) (*AQIResult, error)
+	// StreamAQI returns a result for every station observation, an
+	// observation failing to calculate is answered with its error.
+	StreamAQI(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[Observation, AQIResult], error)
+}
+
+type aQIServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAQIServiceClient(cc grpc.ClientConnInterface) AQIServiceClient {
+	return &aQIServiceClient{cc}
+}
+
+func (c *aQIServiceClient) ListStandards(ctx context.Context, in *ListStandardsRequest, opts ...grpc.CallOption) (*ListStandardsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListStandardsResponse)
+	err := c.cc.Invoke(ctx, AQIService_ListStandards_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aQIServiceClient) GetIAQI(ctx context.Context, in *GetIAQIRequest, opts ...grpc.CallOption) (*GetIAQIResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetIAQIResponse)
+	err := c.cc.Invoke(ctx, AQIService_GetIAQI_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aQIServiceClient) GetAQI(ctx context.Context, in *GetAQIRequest, opts ...grpc.CallOption) (*AQIResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AQIResult)
+	err := c.cc.Invoke(ctx, AQIService_GetAQI_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aQIServiceClient) StreamAQI(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[Observation, AQIResult], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AQIService_ServiceDesc.Streams[0], AQIService_StreamAQI_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[Observation, AQIResult]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AQIService_StreamAQIClient = grpc.BidiStreamingClient[Observation, AQIResult]
+
+// AQIServiceServer is the server API for AQIService service.
+// All implementations must embed UnimplementedAQIServiceServer
+// for forward compatibility.
+type AQIServiceServer interface {
+	// ListStandards returns all registered standards.
+	ListStandards(context.Context, *ListStandardsRequest) (*ListStandardsResponse, error)
+	// GetIAQI returns the individual index of a pollutant.
+	GetIAQI(context.Context, *GetIAQIRequest) (*GetIAQIResponse, error)
+	// GetAQI returns the composite index of concentrations.
+	GetAQI(context.Context, *GetAQIRequest) (*AQIResult, error)
+	// StreamAQI returns a result for every station observation, an
+	// observation failing to calculate is answered with its error.
+	StreamAQI(grpc.BidiStreamingServer[Observation, AQIResult]) error
+	mustEmbedUnimplementedAQIServiceServer()
+}
+
+// UnimplementedAQIServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAQIServiceServer struct{}
+
+func (UnimplementedAQIServiceServer) ListStandards(context.Context, *ListStandardsRequest) (*ListStandardsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStandards not implemented")
+}
+func (UnimplementedAQIServiceServer) GetIAQI(context.Context, *GetIAQIRequest) (*GetIAQIResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetIAQI not implemented")
+}
+func (UnimplementedAQIServiceServer) GetAQI(context.Context, *GetAQIRequest) (*AQIResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAQI not implemented")
+}
+func (UnimplementedAQIServiceServer) StreamAQI(grpc.BidiStreamingServer[Observation, AQIResult]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamAQI not implemented")
+}
+func (UnimplementedAQIServiceServer) mustEmbedUnimplementedAQIServiceServer() {}
+func (UnimplementedAQIServiceServer) testEmbeddedByValue()                    {}
+
+// UnsafeAQIServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AQIServiceServer will
+// result in compilation errors.
+type UnsafeAQIServiceServer interface {
+	mustEmbedUnimplementedAQIServiceServer()
+}
+
+func RegisterAQIServiceServer(s grpc.ServiceRegistrar, srv AQIServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAQIServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AQIService_ServiceDesc, srv)
+}
+
+func _AQIService_ListStandards_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStandardsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AQIServiceServer).ListStandards(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AQIService_ListStandards_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AQIServiceServer).ListStandards(ctx, req.(*ListStandardsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AQIService_GetIAQI_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetIAQIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AQIServiceServer).GetIAQI(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AQIService_GetIAQI_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AQIServiceServer).GetIAQI(ctx, req.(*GetIAQIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AQIService_GetAQI_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAQIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AQIServiceServer).GetAQI(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AQIService_GetAQI_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AQIServiceServer).GetAQI(ctx, req.(*GetAQIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AQIService_StreamAQI_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AQIServiceServer).StreamAQI(&grpc.GenericServerStream[Observation, AQIResult]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AQIService_StreamAQIServer = grpc.BidiStreamingServer[Observation, AQIResult]
+
+// AQIService_ServiceDesc is the grpc.ServiceDesc for AQIService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AQIService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "aqi.v1.AQIService",
+	HandlerType: (*AQIServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListStandards",
+			Handler:    _AQIService_ListStandards_Handler,
+		},
+		{
+			MethodName: "GetIAQI",
+			Handler:    _AQIService_GetIAQI_Handler,
+		},
+		{
+			MethodName: "GetAQI",
+			Handler:    _AQIService_GetAQI_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamAQI",
+			Handler:       _AQIService_StreamAQI_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "aqi/v1/aqi.proto",
+}
@@ -0,0 +1,5 @@
+// Package aqipb holds the protobuf messages and gRPC service of
+// proto/aqi/v1/aqi.proto
+package aqipb
+
+//go:generate protoc -I ../../proto --go_out=../.. --go_opt=module=github.com/elvuel/go-aqi --go-grpc_out=../.. --go-grpc_opt=module=github.com/elvuel/go-aqi aqi/v1/aqi.proto
@@ -0,0 +1,166 @@
+// Package grpcserver serves AQI calculation over gRPC
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strconv"
+
+	aqi "github.com/elvuel/go-aqi"
+	"github.com/elvuel/go-aqi/grpcserver/aqipb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server implements aqipb.AQIServiceServer with the registered standards
+type Server struct {
+	aqipb.UnimplementedAQIServiceServer
+}
+
+func NewServer() *Server {
+	return &Server{}
+}
+
+// Register registers a new Server to s
+func Register(s *grpc.Server) {
+	aqipb.RegisterAQIServiceServer(s, NewServer())
+}
+
+func getStandard(name string) (aqi.Standard, error) {
+	std, err := aqi.GetStandard(name)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return std, nil
+}
+
+// supported fails for pollutants the standard doesn't support, which the
+// standards may index as 0 for a 0 concentration
+func supported(std aqi.Standard, pollutant string) error {
+	for _, v := range std.Pollutants() {
+		if v == pollutant {
+			return nil
+		}
+	}
+	return errors.New("Standard " + std.Name() + " does not support " + strconv.Quote(pollutant))
+}
+
+func newCategory(category aqi.Category) *aqipb.Category {
+	return &aqipb.Category{
+		Level:       int32(category.Level),
+		Name:        category.Name,
+		From:        int32(category.From),
+		To:          int32(category.To),
+		ColorName:   category.ColorName,
+		Color:       category.Color.RGBToHex(),
+		Description: category.Description,
+		Advice:      category.Advice,
+	}
+}
+
+// categoryOf returns nil when the standard has no categories
+func categoryOf(std aqi.Standard, index int) *aqipb.Category {
+	category, err := aqi.GetCategory(std, index)
+	if err != nil {
+		return nil
+	}
+	return newCategory(category)
+}
+
+func (s *Server) ListStandards(ctx context.Context, req *aqipb.ListStandardsRequest) (*aqipb.ListStandardsResponse, error) {
+	result := &aqipb.ListStandardsResponse{}
+	for _, name := range aqi.StandardNames() {
+		std, _ := aqi.GetStandard(name)
+		standard := &aqipb.Standard{Name: name, Pollutants: std.Pollutants()}
+		if categorizer, ok := std.(aqi.Categorizer); ok {
+			for _, category := range categorizer.Categories() {
+				standard.Categories = append(standard.Categories, newCategory(category))
+			}
+		}
+		result.Standards = append(result.Standards, standard)
+	}
+	return result, nil
+}
+
+func (s *Server) GetIAQI(ctx context.Context, req *aqipb.GetIAQIRequest) (*aqipb.GetIAQIResponse, error) {
+	std, err := getStandard(req.GetStandard())
+	if err != nil {
+		return nil, err
+	}
+	if err = supported(std, req.GetPollutant()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	iaqi, err := std.IAQI(req.GetPollutant(), req.GetConcentration())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &aqipb.GetIAQIResponse{
+		Standard:      std.Name(),
+		Pollutant:     req.GetPollutant(),
+		Concentration: req.GetConcentration(),
+		Iaqi:          int32(iaqi),
+		Category:      categoryOf(std, iaqi),
+	}, nil
+}
+
+// calculate fills result with the calculation of concentrations
+func calculate(std aqi.Standard, concentrations map[string]float64, result *aqipb.AQIResult) error {
+	for pollutant := range concentrations {
+		if err := supported(std, pollutant); err != nil {
+			return err
+		}
+	}
+	calculation, err := aqi.Calculate(std, concentrations)
+	if err != nil {
+		return err
+	}
+	result.Standard = std.Name()
+	result.Concentrations = concentrations
+	result.Iaqis = make(map[string]int32)
+	for pollutant, iaqi := range calculation.IAQIs {
+		result.Iaqis[pollutant] = int32(iaqi)
+	}
+	result.Aqi = int32(calculation.AQI)
+	result.ResponsiblePollutants = calculation.ResponsiblePollutants
+	result.Category = categoryOf(std, calculation.AQI)
+	return nil
+}
+
+func (s *Server) GetAQI(ctx context.Context, req *aqipb.GetAQIRequest) (*aqipb.AQIResult, error) {
+	std, err := getStandard(req.GetStandard())
+	if err != nil {
+		return nil, err
+	}
+	result := &aqipb.AQIResult{}
+	if err = calculate(std, req.GetConcentrations(), result); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return result, nil
+}
+
+func (s *Server) StreamAQI(stream aqipb.AQIService_StreamAQIServer) error {
+	for {
+		observation, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		result := &aqipb.AQIResult{
+			Station:  observation.GetStation(),
+			Standard: observation.GetStandard(),
+			Time:     observation.GetTime(),
+		}
+		if std, err := aqi.GetStandard(observation.GetStandard()); err != nil {
+			result.Error = err.Error()
+		} else if err = calculate(std, observation.GetConcentrations(), result); err != nil {
+			result.Error = err.Error()
+		}
+		if err = stream.Send(result); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package grpcserver
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/elvuel/go-aqi/grpcserver/aqipb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func newClient(t *testing.T) aqipb.AQIServiceClient {
+	listener := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	Register(s)
+	go s.Serve(listener)
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+		s.Stop()
+	})
+	return aqipb.NewAQIServiceClient(conn)
+}
+
+func TestListStandards(t *testing.T) {
+	client := newClient(t)
+	resp, err := client.ListStandards(context.Background(), &aqipb.ListStandardsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := make(map[string]*aqipb.Standard)
+	for _, std := range resp.GetStandards() {
+		names[std.GetName()] = std
+	}
+	if std, ok := names["mep"]; !ok || len(std.GetPollutants()) != 10 || len(std.GetCategories()) != 6 {
+		t.Errorf("unexpected mep %v", std)
+	}
+}
+
+func TestGetIAQI(t *testing.T) {
+	client := newClient(t)
+	resp, err := client.GetIAQI(context.Background(), &aqipb.GetIAQIRequest{Standard: "mep", Pollutant: "pm25_24h", Concentration: 64})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetIaqi() != 87 || resp.GetCategory().GetColor() != "#FFFF00" {
+		t.Errorf("unexpected response %v", resp)
+	}
+
+	_, err = client.GetIAQI(context.Background(), &aqipb.GetIAQIRequest{Standard: "foo", Pollutant: "pm25_24h", Concentration: 64})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("fake foo standard should be not found, but %v", err)
+	}
+	for _, concentration := range []float64{64, 0} {
+		_, err = client.GetIAQI(context.Background(), &aqipb.GetIAQIRequest{Standard: "mep", Pollutant: "foo", Concentration: concentration})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("fake foo pollutant of %v should be invalid argument, but %v", concentration, err)
+		}
+	}
+}
+
+func TestGetAQI(t *testing.T) {
+	client := newClient(t)
+	resp, err := client.GetAQI(context.Background(), &aqipb.GetAQIRequest{
+		Standard:       "epa",
+		Concentrations: map[string]float64{"co_8h": 8.4, "o3_8h": 0.08742, "pm25_24h": 40.9},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetAqi() != 129 || resp.GetIaqis()["pm25_24h"] != 114 || resp.GetResponsiblePollutants()[0] != "o3_8h" {
+		t.Errorf("unexpected response %v", resp)
+	}
+	_, err = client.GetAQI(context.Background(), &aqipb.GetAQIRequest{
+		Standard:       "epa",
+		Concentrations: map[string]float64{"pm25_24h": 40.9, "foo": 0},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("fake foo pollutant should be invalid argument, but %v", err)
+	}
+}
+
+func TestStreamAQI(t *testing.T) {
+	client := newClient(t)
+	stream, err := client.StreamAQI(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := timestamppb.New(time.Date(2013, 1, 15, 8, 0, 0, 0, time.UTC))
+	observations := []*aqipb.Observation{
+		{Station: "1001", Standard: "mep", Time: now, Concentrations: map[string]float64{"pm25_24h": 82, "pm10_24h": 113}},
+		{Station: "1002", Standard: "foo", Time: now, Concentrations: map[string]float64{"pm25_24h": 82}},
+		{Station: "1003", Standard: "epa", Time: now, Concentrations: map[string]float64{"so2_24h": 10}},
+	}
+	for _, observation := range observations {
+		if err = stream.Send(observation); err != nil {
+			t.Fatal(err)
+		}
+		result, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.GetStation() != observation.GetStation() || !result.GetTime().AsTime().Equal(now.AsTime()) {
+			t.Errorf("unexpected result %v", result)
+		}
+		switch observation.GetStation() {
+		case "1001":
+			if result.GetAqi() != 109 || result.GetError() != "" {
+				t.Errorf("unexpected result %v", result)
+			}
+		default:
+			if result.GetError() == "" {
+				t.Errorf("%s should fail with error", observation.GetStation())
+			}
+		}
+	}
+	if err = stream.CloseSend(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,267 @@
+package aqi
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultLocale is the locale every other locale falls back to
+const DefaultLocale = "en"
+
+// Catalog is the messages of a locale keyed by message key:
+//
+//	category.<standard>.<level>.name|description|advice
+//	color.<color name>, e.g. color.GREEN
+//	species.<species>, e.g. species.pm25
+//	pollutant.format, e.g. "{species} {hours}-hour"
+type Catalog map[string]string
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+var (
+	catalogsMu sync.RWMutex
+	catalogs   = make(map[string]Catalog)
+)
+
+func init() {
+	if err := LoadCatalogFS(localeFiles, "locales/*.json"); err != nil {
+		panic(err)
+	}
+}
+
+// CanonicalLocale returns the canonical form of a BCP-47 tag, e.g. zh-Hant-TW
+// for zh_hant_tw
+func CanonicalLocale(tag string) (string, error) {
+	parts := strings.FieldsFunc(tag, func(r rune) bool { return r == '-' || r == '_' })
+	if len(parts) == 0 || len(parts[0]) < 2 || len(parts[0]) > 3 || !isAlpha(parts[0]) {
+		return "", errors.New("Invalid locale " + tag)
+	}
+	parts[0] = strings.ToLower(parts[0])
+	for i := 1; i < len(parts); i++ {
+		part := parts[i]
+		switch {
+		case len(part) == 4 && isAlpha(part):
+			parts[i] = strings.ToUpper(part[:1]) + strings.ToLower(part[1:])
+		case len(part) == 2 && isAlpha(part):
+			parts[i] = strings.ToUpper(part)
+		default:
+			parts[i] = strings.ToLower(part)
+		}
+	}
+	return strings.Join(parts, "-"), nil
+}
+
+func isAlpha(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// localeFallbacks returns a canonical tag and the tags it falls back to,
+// from the most specific one to DefaultLocale. Chinese without a script
+// falls back to zh-Hant in TW, HK and MO and to zh-Hans elsewhere
+func localeFallbacks(tag string) []string {
+	parts := strings.Split(tag, "-")
+	result := make([]string, 0, len(parts)+2)
+	for i := len(parts); i > 0; i-- {
+		result = append(result, strings.Join(parts[:i], "-"))
+	}
+	if parts[0] == "zh" && (len(parts) < 2 || len(parts[1]) != 4) {
+		script := "zh-Hans"
+		if len(parts) > 1 && (parts[1] == "TW" || parts[1] == "HK" || parts[1] == "MO") {
+			script = "zh-Hant"
+		}
+		result = append(result[:len(result)-1], script, "zh")
+	}
+	if parts[0] != DefaultLocale {
+		result = append(result, DefaultLocale)
+	}
+	return result
+}
+
+// RegisterCatalog merges the messages of a catalog into the catalog of a
+// locale, replacing the messages with the same key
+func RegisterCatalog(tag string, catalog Catalog) error {
+	tag, err := CanonicalLocale(tag)
+	if err != nil {
+		return err
+	}
+	catalogsMu.Lock()
+	defer catalogsMu.Unlock()
+	merged, ok := catalogs[tag]
+	if !ok {
+		merged = make(Catalog)
+		catalogs[tag] = merged
+	}
+	for key, message := range catalog {
+		merged[key] = message
+	}
+	return nil
+}
+
+// LoadCatalog reads a catalog in a JSON object of messages keyed by message
+// key
+func LoadCatalog(r io.Reader) (Catalog, error) {
+	var catalog Catalog
+	if err := json.NewDecoder(r).Decode(&catalog); err != nil {
+		return nil, err
+	}
+	return catalog, nil
+}
+
+// LoadCatalogFile registers the catalog of a JSON file named by its locale,
+// e.g. pt-BR.json
+func LoadCatalogFile(name string) error {
+	file, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return loadCatalog(filepath.Base(name), file)
+}
+
+// LoadCatalogFS registers the catalogs of the JSON files matching pattern in
+// fsys, e.g. LoadCatalogFS(os.DirFS("locales"), "*.json")
+func LoadCatalogFS(fsys fs.FS, pattern string) error {
+	names, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		file, err := fsys.Open(name)
+		if err != nil {
+			return err
+		}
+		err = loadCatalog(path.Base(name), file)
+		file.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func loadCatalog(base string, r io.Reader) error {
+	catalog, err := LoadCatalog(r)
+	if err != nil {
+		return errors.New(base + ": " + err.Error())
+	}
+	return RegisterCatalog(strings.TrimSuffix(base, path.Ext(base)), catalog)
+}
+
+// Locales returns the sorted locales with a catalog
+func Locales() []string {
+	catalogsMu.RLock()
+	defer catalogsMu.RUnlock()
+	result := make([]string, 0, len(catalogs))
+	for tag := range catalogs {
+		result = append(result, tag)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// Localizer localizes categories, colors and pollutant names in a locale,
+// messages missing in it fall back to its parent locales and DefaultLocale,
+// then to the text of the standard
+type Localizer struct {
+	locales []string
+}
+
+// NewLocalizer returns the localizer of a BCP-47 tag, e.g. zh-TW
+func NewLocalizer(tag string) (*Localizer, error) {
+	tag, err := CanonicalLocale(tag)
+	if err != nil {
+		return nil, err
+	}
+	return &Localizer{locales: localeFallbacks(tag)}, nil
+}
+
+// Locale returns the most specific locale of the localizer with a catalog
+func (l *Localizer) Locale() string {
+	catalogsMu.RLock()
+	defer catalogsMu.RUnlock()
+	for _, tag := range l.locales {
+		if _, ok := catalogs[tag]; ok {
+			return tag
+		}
+	}
+	return DefaultLocale
+}
+
+// Message returns the message of a key, ok is false when no locale of the
+// localizer has it
+func (l *Localizer) Message(key string) (message string, ok bool) {
+	catalogsMu.RLock()
+	defer catalogsMu.RUnlock()
+	for _, tag := range l.locales {
+		if message, ok = catalogs[tag][key]; ok {
+			return message, true
+		}
+	}
+	return "", false
+}
+
+func (l *Localizer) messageOr(key, text string) string {
+	if message, ok := l.Message(key); ok {
+		return message
+	}
+	return text
+}
+
+// Category returns the category of a standard with its name, description,
+// advice and color name localized
+func (l *Localizer) Category(std Standard, category Category) Category {
+	prefix := "category." + std.Name() + "." + strconv.Itoa(category.Level) + "."
+	category.Name = l.messageOr(prefix+"name", category.Name)
+	category.Description = l.messageOr(prefix+"description", category.Description)
+	category.Advice = l.messageOr(prefix+"advice", category.Advice)
+	category.ColorName = l.ColorName(category.ColorName)
+	return category
+}
+
+// GetCategory returns the localized category of an index value
+func (l *Localizer) GetCategory(std Standard, aqi int) (Category, error) {
+	category, err := GetCategory(std, aqi)
+	if err != nil {
+		return category, err
+	}
+	return l.Category(std, category), nil
+}
+
+// ColorName localizes a color name of a standard, e.g. GREEN
+func (l *Localizer) ColorName(name string) string {
+	return l.messageOr("color."+name, name)
+}
+
+// SpeciesName localizes a species, e.g. pm25
+func (l *Localizer) SpeciesName(species string) string {
+	return l.messageOr("species."+species, species)
+}
+
+// PollutantName localizes a pollutant tag with its species and averaging
+// period, e.g. "PM₂.₅ 24-hour" for pm25_24h
+func (l *Localizer) PollutantName(tag string) string {
+	hours, err := AveragingHours(tag)
+	if err != nil {
+		return tag
+	}
+	return strings.NewReplacer(
+		"{species}", l.SpeciesName(species(tag)),
+		"{hours}", strconv.Itoa(hours),
+	).Replace(l.messageOr("pollutant.format", "{species} {hours}h"))
+}
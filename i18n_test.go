@@ -0,0 +1,145 @@
+package aqi
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestCanonicalLocale(t *testing.T) {
+	seeds := map[string]string{"zh_hant_tw": "zh-Hant-TW", "ES-mx": "es-MX", "en": "en", "es-419": "es-419"}
+	for tag, expected := range seeds {
+		if v, err := CanonicalLocale(tag); err != nil || v != expected {
+			t.Errorf("%s should be %s, but %s %v", tag, expected, v, err)
+		}
+	}
+	for _, tag := range []string{"", "x", "1a-US", "english"} {
+		if _, err := CanonicalLocale(tag); err == nil {
+			t.Errorf("%q should fail with error", tag)
+		}
+	}
+}
+
+func TestLocalizerLocale(t *testing.T) {
+	seeds := map[string]string{
+		"en-US": "en", "zh": "zh-Hans", "zh-CN": "zh-Hans", "zh-SG": "zh-Hans", "zh-TW": "zh-Hant",
+		"zh-HK": "zh-Hant", "zh-Hant-TW": "zh-Hant", "es-MX": "es", "fr-FR": "en",
+	}
+	for tag, expected := range seeds {
+		l, err := NewLocalizer(tag)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v := l.Locale(); v != expected {
+			t.Errorf("%s should be localized in %s, but %s", tag, expected, v)
+		}
+	}
+	if v := strings.Join(Locales(), " "); !strings.Contains(v, "en es zh-Hans zh-Hant") {
+		t.Errorf("built-in locales should be loaded, but %s", v)
+	}
+}
+
+func TestLocalizerCategory(t *testing.T) {
+	type Seed struct {
+		Tag      string
+		Standard Standard
+		AQI      int
+		Name     string
+		Color    string
+	}
+	seeds := []Seed{
+		{"en", MepStandard, 20, "Excellent", "Green"},
+		{"zh-CN", MepStandard, 20, "优", "绿色"},
+		{"zh-TW", MepStandard, 20, "優", "綠色"},
+		{"es", MepStandard, 20, "Excelente", "Verde"},
+		{"es-MX", EpaStandard, 20, "Buena", "Verde"},
+		{"zh-Hans", EpaStandard, 120, "对敏感人群不健康", "橙色"},
+		{"en", MexStandard, 20, "Good", "Green"},
+		{"es", MexStandard, 20, "Buena", "Verde"},
+		{"fr", EpaStandard, 20, "Good", "Green"},
+	}
+	for _, seed := range seeds {
+		l, _ := NewLocalizer(seed.Tag)
+		category, err := l.GetCategory(seed.Standard, seed.AQI)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if category.Name != seed.Name || category.ColorName != seed.Color {
+			t.Errorf("%s %s %d should be %s %s, but %s %s", seed.Tag, seed.Standard.Name(), seed.AQI, seed.Name, seed.Color, category.Name, category.ColorName)
+		}
+	}
+
+	l, _ := NewLocalizer("zh-Hans")
+	category, _ := l.GetCategory(MepStandard, 120)
+	if category.Advice != "儿童、老年人及心脏病、呼吸系统疾病患者应减少长时间、高强度的户外锻炼。" || category.Level != 3 || category.Color != mepColors[2].Color {
+		t.Errorf("mep level 3 advice should be localized, but %+v", category)
+	}
+	// every category of the built-in standards has a name in every locale
+	for _, locale := range []string{"en", "zh-Hans", "zh-Hant", "es"} {
+		for _, name := range StandardNames() {
+			std, _ := GetStandard(name)
+			for _, c := range std.(Categorizer).Categories() {
+				key := "category." + name + "." + strconv.Itoa(c.Level) + ".name"
+				if locale != "en" || name == "mex" {
+					if _, ok := catalogs[locale][key]; !ok {
+						t.Errorf("%s should have %s", locale, key)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestLocalizerPollutantName(t *testing.T) {
+	seeds := map[string]string{"en": "PM₂.₅ 24-hour", "zh-Hans": "细颗粒物24小时平均", "zh-Hant": "細懸浮微粒24小時平均", "es": "PM₂.₅ 24 horas"}
+	for tag, expected := range seeds {
+		l, _ := NewLocalizer(tag)
+		if v := l.PollutantName("pm25_24h"); v != expected {
+			t.Errorf("%s pm25_24h should be %s, but %s", tag, expected, v)
+		}
+	}
+	l, _ := NewLocalizer("zh")
+	if v := l.SpeciesName("o3"); v != "臭氧" {
+		t.Errorf("o3 should be 臭氧, but %s", v)
+	}
+	if v := l.PollutantName("bogus"); v != "bogus" {
+		t.Errorf("invalid tag should be kept, but %s", v)
+	}
+}
+
+func TestLoadCatalogFile(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "fr.json")
+	if err := os.WriteFile(name, []byte(`{"category.epa.1.name": "Bon", "color.GREEN": "Vert"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadCatalogFile(name); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		catalogsMu.Lock()
+		delete(catalogs, "fr")
+		catalogsMu.Unlock()
+	}()
+	l, _ := NewLocalizer("fr-CA")
+	category, _ := l.GetCategory(EpaStandard, 10)
+	if l.Locale() != "fr" || category.Name != "Bon" || category.ColorName != "Vert" {
+		t.Errorf("fr catalog should be loaded, but %s %s %s", l.Locale(), category.Name, category.ColorName)
+	}
+	// messages missing in fr fall back to en
+	if v := l.PollutantName("o3_8h"); v != "O₃ 8-hour" {
+		t.Errorf("fr o3_8h should fall back to en, but %s", v)
+	}
+
+	if err := os.WriteFile(name, []byte(`{"color.RED": 1}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadCatalogFile(name); err == nil {
+		t.Error("invalid catalog should fail with error")
+	}
+	if err := LoadCatalogFS(os.DirFS(dir), "*.json"); err == nil {
+		t.Error("invalid catalog in fs should fail with error")
+	}
+}
@@ -0,0 +1,191 @@
+package aqi
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+)
+
+// TagError is the error of concentrations keyed by tags unknown to every
+// registered standard, or unsupported by the standard they are for, e.g.
+// so2_24h under EPA
+type TagError struct {
+	Standard    string
+	Unknown     []string
+	Unsupported []string
+}
+
+func (err *TagError) Error() string {
+	parts := make([]string, 0, 2)
+	if len(err.Unknown) > 0 {
+		parts = append(parts, "Unknown pollutant tags "+strings.Join(err.Unknown, ", "))
+	}
+	if len(err.Unsupported) > 0 {
+		parts = append(parts, "Pollutant tags "+strings.Join(err.Unsupported, ", ")+" unsupported by "+err.Standard)
+	}
+	return strings.Join(parts, "; ")
+}
+
+// checkTags returns a *TagError of the tags std doesn't support
+func checkTags(std Standard, tags []string) error {
+	supported := make(map[string]bool)
+	for _, tag := range std.Pollutants() {
+		supported[tag] = true
+	}
+	known := make(map[string]bool)
+	for _, info := range RegisteredPollutants() {
+		known[info.Tag] = true
+	}
+	err := &TagError{Standard: std.Name()}
+	for _, tag := range tags {
+		if supported[tag] {
+			continue
+		}
+		if known[tag] {
+			err.Unsupported = append(err.Unsupported, tag)
+		} else {
+			err.Unknown = append(err.Unknown, tag)
+		}
+	}
+	if len(err.Unknown) == 0 && len(err.Unsupported) == 0 {
+		return nil
+	}
+	sort.Strings(err.Unknown)
+	sort.Strings(err.Unsupported)
+	return err
+}
+
+// NewEpaPollutantFromMap builds an EpaPollutant from concentrations keyed by
+// its json tags, other tags fail with a *TagError
+func NewEpaPollutantFromMap(concentrations map[string]float64) (*EpaPollutant, error) {
+	epa := &EpaPollutant{}
+	if err := setStructConcentrations(EpaStandard, epa, concentrations); err != nil {
+		return nil, err
+	}
+	return epa, nil
+}
+
+// NewMepPollutantFromMap builds a MepPollutant from concentrations keyed by
+// its json tags, other tags fail with a *TagError
+func NewMepPollutantFromMap(concentrations map[string]float64) (*MepPollutant, error) {
+	mep := &MepPollutant{}
+	if err := setStructConcentrations(MepStandard, mep, concentrations); err != nil {
+		return nil, err
+	}
+	return mep, nil
+}
+
+// DecodeConcentrations strictly decodes a JSON object of concentrations of
+// a standard keyed by pollutant tag. Tags the standard doesn't support fail
+// with a *TagError, so do duplicated tags, anything but a single object and
+// data after it. null values are left out. A value is a number in the unit
+// of the standard or a string with a unit suffix converted to it, e.g.
+//
+//	{"pm25_24h": 35.4, "o3_8h": "70 ppb", "co_8h": "5 mg/m³"}
+func DecodeConcentrations(r io.Reader, std Standard) (map[string]float64, error) {
+	raw, err := decodeObject(json.NewDecoder(r))
+	if err != nil {
+		return nil, err
+	}
+	tags := make([]string, 0, len(raw))
+	for tag := range raw {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	if err := checkTags(std, tags); err != nil {
+		return nil, err
+	}
+	result := make(map[string]float64)
+	for _, tag := range tags {
+		if string(raw[tag]) == "null" {
+			continue
+		}
+		v, err := decodeConcentration(std, tag, raw[tag])
+		if err != nil {
+			return nil, errors.New(tag + ": " + err.Error())
+		}
+		result[tag] = v
+	}
+	return result, nil
+}
+
+// decodeObject decodes the only JSON object of dec by walking its tokens,
+// which unlike decoding into a map catches duplicated keys
+func decodeObject(dec *json.Decoder) (map[string]json.RawMessage, error) {
+	token, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return nil, errors.New("Concentrations should be a JSON object")
+	}
+	result := make(map[string]json.RawMessage)
+	for dec.More() {
+		if token, err = dec.Token(); err != nil {
+			return nil, err
+		}
+		key := token.(string)
+		if _, ok := result[key]; ok {
+			return nil, errors.New("Duplicated pollutant tag " + key)
+		}
+		var value json.RawMessage
+		if err = dec.Decode(&value); err != nil {
+			return nil, err
+		}
+		result[key] = value
+	}
+	// the closing brace
+	if _, err = dec.Token(); err != nil {
+		return nil, err
+	}
+	if _, err = dec.Token(); err != io.EOF {
+		return nil, errors.New("Unexpected data after concentrations")
+	}
+	return result, nil
+}
+
+func decodeConcentration(std Standard, tag string, data json.RawMessage) (float64, error) {
+	var v float64
+	if err := json.Unmarshal(data, &v); err == nil {
+		return v, nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return 0, errors.New("Invalid concentration " + string(data))
+	}
+	v, unit, err := ParseQuantity(s)
+	if err != nil || unit == "" {
+		return v, err
+	}
+	uniter, ok := std.(Uniter)
+	if !ok {
+		return 0, errors.New("Standard " + std.Name() + " has no units")
+	}
+	target, err := uniter.Unit(tag)
+	if err != nil {
+		return 0, err
+	}
+	return ConvertUnit(species(tag), v, unit, target)
+}
+
+// DecodeEpaPollutant strictly decodes an EpaPollutant, see
+// DecodeConcentrations
+func DecodeEpaPollutant(r io.Reader) (*EpaPollutant, error) {
+	concentrations, err := DecodeConcentrations(r, EpaStandard)
+	if err != nil {
+		return nil, err
+	}
+	return NewEpaPollutantFromMap(concentrations)
+}
+
+// DecodeMepPollutant strictly decodes a MepPollutant, see
+// DecodeConcentrations
+func DecodeMepPollutant(r io.Reader) (*MepPollutant, error) {
+	concentrations, err := DecodeConcentrations(r, MepStandard)
+	if err != nil {
+		return nil, err
+	}
+	return NewMepPollutantFromMap(concentrations)
+}
@@ -0,0 +1,84 @@
+package aqi
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestNewPollutantFromMap(t *testing.T) {
+	epa, err := NewEpaPollutantFromMap(map[string]float64{"pm25_24h": 35.9, "o3_8h": 0.078})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if epa.PM25Pollutant24H != 35.9 || epa.O3Pollutant8H != 0.078 || epa.GetAQI() != 106 {
+		t.Errorf("epa should be built from the map, but %+v %d", *epa, epa.GetAQI())
+	}
+
+	mep, err := NewMepPollutantFromMap(map[string]float64{"pm25_24h": 82, "so2_24h": 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mep.PM25Pollutant24H != 82 || mep.SO2Pollutant24H != 10 || mep.GetAQI() != 109 {
+		t.Errorf("mep should be built from the map, but %+v %d", *mep, mep.GetAQI())
+	}
+
+	_, err = NewEpaPollutantFromMap(map[string]float64{"so2_24h": 10, "pm26_24h": 1, "pm25_24h": 10})
+	tagErr, ok := err.(*TagError)
+	if !ok {
+		t.Fatalf("unsupported tags should fail with *TagError, but %v", err)
+	}
+	if tagErr.Standard != "epa" || strings.Join(tagErr.Unknown, ",") != "pm26_24h" || strings.Join(tagErr.Unsupported, ",") != "so2_24h" {
+		t.Errorf("so2_24h should be unsupported and pm26_24h unknown, but %+v", tagErr)
+	}
+	if err.Error() != "Unknown pollutant tags pm26_24h; Pollutant tags so2_24h unsupported by epa" {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestDecodeConcentrations(t *testing.T) {
+	concentrations, err := DecodeConcentrations(strings.NewReader(`{"pm25_24h": 35.4, "o3_8h": "70 ppb", "co_8h": "5 mg/m3", "so2_1h": "12", "no2_1h": null}`), EpaStandard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]float64{"pm25_24h": 35.4, "o3_8h": 0.07, "co_8h": 4.364, "so2_1h": 12}
+	if len(concentrations) != len(expected) {
+		t.Errorf("concentrations should be %v, but %v", expected, concentrations)
+	}
+	for tag, v := range expected {
+		if math.Abs(concentrations[tag]-v) > 1e-3 {
+			t.Errorf("%s should be %v, but %v", tag, v, concentrations[tag])
+		}
+	}
+
+	mep, err := DecodeMepPollutant(strings.NewReader(`{"so2_1h": "100 ppb", "pm25_24h": "82 ug/m3"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(mep.SO2Pollutant1H-262.03) > 1e-2 || mep.PM25Pollutant24H != 82 {
+		t.Errorf("mep should be converted to µg/m³, but %+v", *mep)
+	}
+
+	type Seed struct {
+		JSON string
+		Err  string
+	}
+	seeds := []Seed{
+		{`{"so2_24h": 10}`, "Pollutant tags so2_24h unsupported by epa"},
+		{`{"pm2.5": 10}`, "Unknown pollutant tags pm2.5"},
+		{`{"pm25_24h": "35 ppb"}`, "pm25_24h: No molecular weight of pm25"},
+		{`{"pm25_24h": true}`, "pm25_24h: Invalid concentration true"},
+		{`[1]`, "Concentrations should be a JSON object"},
+		{`null`, "Concentrations should be a JSON object"},
+		{`{"pm25_24h": 35.4, "pm25_24h": 99}`, "Duplicated pollutant tag pm25_24h"},
+		{`{"pm25_24h": 35.4} {"x": 1}`, "Unexpected data after concentrations"},
+		{`{"pm25_24h": 35.4} x`, "Unexpected data after concentrations"},
+		{`{"pm25_24h": 35.4`, ""},
+		{``, "EOF"},
+	}
+	for _, seed := range seeds {
+		if _, err = DecodeEpaPollutant(strings.NewReader(seed.JSON)); err == nil || !strings.HasPrefix(err.Error(), seed.Err) {
+			t.Errorf("%s should fail with %s, but %v", seed.JSON, seed.Err, err)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package aqi
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// colorJSON is the JSON representation of a color, Hex is derived from RGB.
+// RGB is decoded from Hex when none of r, g and b is given
+type colorJSON struct {
+	Name string `json:"name,omitempty"`
+	Hex  string `json:"hex"`
+	R    *uint8 `json:"r,omitempty"`
+	G    *uint8 `json:"g,omitempty"`
+	B    *uint8 `json:"b,omitempty"`
+	C    *uint8 `json:"c,omitempty"`
+	M    *uint8 `json:"m,omitempty"`
+	Y    *uint8 `json:"y,omitempty"`
+	K    *uint8 `json:"k,omitempty"`
+}
+
+func newColorJSON(name string, color Color) colorJSON {
+	return colorJSON{
+		Name: name,
+		Hex:  color.RGBToHex(),
+		R:    &color.R, G: &color.G, B: &color.B,
+		C: &color.C, M: &color.M, Y: &color.Y, K: &color.K,
+	}
+}
+
+func (v colorJSON) color() (Color, error) {
+	var color Color
+	if v.R == nil && v.G == nil && v.B == nil {
+		if v.Hex == "" {
+			return color, errors.New("Color without hex or rgb")
+		}
+		var err error
+		if color, err = ParseHexColor(v.Hex); err != nil {
+			return color, err
+		}
+	}
+	for _, c := range []struct {
+		v   *uint8
+		dst *uint8
+	}{{v.R, &color.R}, {v.G, &color.G}, {v.B, &color.B}, {v.C, &color.C}, {v.M, &color.M}, {v.Y, &color.Y}, {v.K, &color.K}} {
+		if c.v != nil {
+			*c.dst = *c.v
+		}
+	}
+	return color, nil
+}
+
+func unmarshalColor(data []byte) (string, Color, error) {
+	var v colorJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return "", Color{}, err
+	}
+	color, err := v.color()
+	return v.Name, color, err
+}
+
+// ParseHexColor parses an RGB color in "#RRGGBB" or "RRGGBB"
+func ParseHexColor(s string) (Color, error) {
+	hex := strings.TrimPrefix(s, "#")
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if len(hex) != 6 || err != nil {
+		return Color{}, errors.New("Invalid hex color " + s)
+	}
+	return Color{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v)}, nil
+}
+
+// MarshalText encodes the RGB of the color in hex, CMYK is dropped
+func (color Color) MarshalText() ([]byte, error) {
+	return []byte(color.RGBToHex()), nil
+}
+
+func (color *Color) UnmarshalText(text []byte) error {
+	c, err := ParseHexColor(string(text))
+	if err == nil {
+		*color = c
+	}
+	return err
+}
+
+// MarshalJSON encodes the color with its hex, RGB and CMYK
+func (color Color) MarshalJSON() ([]byte, error) {
+	return json.Marshal(newColorJSON("", color))
+}
+
+// UnmarshalJSON decodes a color object or a hex string
+func (color *Color) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		return color.UnmarshalText([]byte(s))
+	}
+	_, c, err := unmarshalColor(data)
+	if err == nil {
+		*color = c
+	}
+	return err
+}
+
+func (color EpaColor) MarshalJSON() ([]byte, error) {
+	return json.Marshal(newColorJSON(color.Name, color.Color))
+}
+
+func (color *EpaColor) UnmarshalJSON(data []byte) error {
+	var err error
+	color.Name, color.Color, err = unmarshalColor(data)
+	return err
+}
+
+func (color MepColor) MarshalJSON() ([]byte, error) {
+	return json.Marshal(newColorJSON(color.Name, color.Color))
+}
+
+func (color *MepColor) UnmarshalJSON(data []byte) error {
+	var err error
+	color.Name, color.Color, err = unmarshalColor(data)
+	return err
+}
+
+func (color MexColor) MarshalJSON() ([]byte, error) {
+	return json.Marshal(newColorJSON(color.Name, color.Color))
+}
+
+func (color *MexColor) UnmarshalJSON(data []byte) error {
+	var err error
+	color.Name, color.Color, err = unmarshalColor(data)
+	return err
+}
+
+// marshalEnum encodes the name of one of the n values of an enum
+func marshalEnum(kind string, v, n int, name string) ([]byte, error) {
+	if v < 0 || v >= n {
+		return nil, errors.New("Invalid " + kind + " " + strconv.Itoa(v))
+	}
+	return []byte(name), nil
+}
+
+// unmarshalEnum decodes the name of one of the n values of an enum
+func unmarshalEnum(kind string, text []byte, n int, name func(int) string) (int, error) {
+	for i := 0; i < n; i++ {
+		if name(i) == string(text) {
+			return i, nil
+		}
+	}
+	return 0, errors.New("Invalid " + kind + " " + string(text))
+}
+
+// jsonFloat encodes NaN and infinities as the strings "NaN", "+Inf" and
+// "-Inf", which JSON numbers can't represent
+type jsonFloat float64
+
+func (v jsonFloat) MarshalJSON() ([]byte, error) {
+	f := float64(v)
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return json.Marshal(strconv.FormatFloat(f, 'g', -1, 64))
+	}
+	return json.Marshal(f)
+}
+
+func (v *jsonFloat) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return json.Unmarshal(data, (*float64)(v))
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return errors.New("Invalid number " + s)
+	}
+	*v = jsonFloat(f)
+	return nil
+}
+
+type sanitationPolicyJSON struct {
+	Mode       SanitationMode     `json:"mode"`
+	Tolerance  jsonFloat          `json:"tolerance"`
+	Tolerances map[string]float64 `json:"tolerances,omitempty"`
+}
+
+// MarshalJSON encodes an infinite tolerance as "+Inf"
+func (policy SanitationPolicy) MarshalJSON() ([]byte, error) {
+	return json.Marshal(sanitationPolicyJSON{policy.Mode, jsonFloat(policy.Tolerance), policy.Tolerances})
+}
+
+func (policy *SanitationPolicy) UnmarshalJSON(data []byte) error {
+	var v sanitationPolicyJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*policy = SanitationPolicy{v.Mode, float64(v.Tolerance), v.Tolerances}
+	return nil
+}
+
+// StandardInfo is the JSON representation of a standard, the break points,
+// categories and policies are omitted when the standard doesn't declare them
+type StandardInfo struct {
+	Name        string             `json:"name"`
+	Pollutants  []string           `json:"pollutants"`
+	Categories  []Category         `json:"categories,omitempty"`
+	BreakPoints map[string][]Band  `json:"breakpoints,omitempty"`
+	Rounding    *RoundingPolicy    `json:"rounding,omitempty"`
+	Sanitation  *SanitationPolicy  `json:"sanitation,omitempty"`
+	BeyondIndex *BeyondIndexPolicy `json:"beyond_index,omitempty"`
+}
+
+// DescribeStandard returns the JSON representation of a standard
+func DescribeStandard(std Standard) StandardInfo {
+	info := StandardInfo{Name: std.Name(), Pollutants: std.Pollutants()}
+	if categorizer, ok := std.(Categorizer); ok {
+		info.Categories = categorizer.Categories()
+	}
+	if breakPointer, ok := std.(BreakPointer); ok {
+		info.BreakPoints = make(map[string][]Band)
+		for _, pollutant := range info.Pollutants {
+			if bands, err := breakPointer.BreakPoints(pollutant); err == nil {
+				info.BreakPoints[pollutant] = bands
+			}
+		}
+	}
+	if rounder, ok := std.(Rounder); ok {
+		policy := rounder.RoundingPolicy()
+		info.Rounding = &policy
+	}
+	if sanitizer, ok := std.(Sanitizer); ok {
+		policy := sanitizer.SanitationPolicy()
+		info.Sanitation = &policy
+	}
+	if beyondIndexer, ok := std.(BeyondIndexer); ok {
+		policy := beyondIndexer.BeyondIndexPolicy()
+		info.BeyondIndex = &policy
+	}
+	return info
+}
@@ -0,0 +1,157 @@
+package aqi
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestColorJSON(t *testing.T) {
+	green := epaColors[0]
+	data, err := json.Marshal(green)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"name":"GREEN","hex":"#00E400","r":0,"g":228,"b":0,"c":40,"m":0,"y":100,"k":0}`
+	if string(data) != expected {
+		t.Errorf("green should be %s, but %s", expected, data)
+	}
+	var color EpaColor
+	if err = json.Unmarshal(data, &color); err != nil || color != green {
+		t.Errorf("green should round trip, but %v %v", color, err)
+	}
+
+	var c Color
+	if err = json.Unmarshal([]byte(`"#7E0023"`), &c); err != nil || c != (Color{R: 126, B: 35}) {
+		t.Errorf("hex string should be decoded, but %v %v", c, err)
+	}
+	if err = json.Unmarshal([]byte(`{"hex":"FF7E00","c":0,"m":52,"y":100}`), &c); err != nil || c != (Color{R: 255, G: 126, M: 52, Y: 100}) {
+		t.Errorf("hex object should be decoded, but %v %v", c, err)
+	}
+	if text, _ := c.MarshalText(); string(text) != "#FF7E00" {
+		t.Errorf("text should be #FF7E00, but %s", text)
+	}
+	for _, s := range []string{`"#FFF"`, `"#GG0000"`, `{}`} {
+		if err = json.Unmarshal([]byte(s), &c); err == nil {
+			t.Errorf("%s should fail with error", s)
+		}
+	}
+}
+
+func TestCategoryJSON(t *testing.T) {
+	category, _ := GetCategory(MepStandard, 109)
+	data, err := json.Marshal(category)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"level":3,"name":"Lightly Polluted","from":101,"to":150,"color_name":"ORANGE","color":{"hex":"#FF7E00"`) {
+		t.Errorf("category JSON should have stable field names and hex color, but %s", data)
+	}
+	var decoded Category
+	if err = json.Unmarshal(data, &decoded); err != nil || decoded != category {
+		t.Errorf("category should round trip, but %v %v", decoded, err)
+	}
+}
+
+func TestResultJSON(t *testing.T) {
+	result, err := CalculateFloat(MepStandard, map[string]float64{"pm25_24h": 82, "pm10_24h": 113, "co_24h": -0.1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"diagnostics":{"co_24h":{"issue":"negative","action":"clamped"}}`) {
+		t.Errorf("diagnostics should be encoded by name, but %s", data)
+	}
+	var decoded FloatResult
+	if err = json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, result) {
+		t.Errorf("result should round trip, but\n%+v\n%+v", decoded, result)
+	}
+
+	if err = json.Unmarshal([]byte(`{"diagnostics":{"pm25_24h":{"issue":"bad"}}}`), &decoded); err == nil {
+		t.Error("unknown issue should fail with error")
+	}
+}
+
+func TestMepReportJSON(t *testing.T) {
+	report, err := NewMepDailyReport(newReportStation(t), time.Date(2013, 1, 15, 0, 0, 0, 0, reportLocation))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"mode":"daily"`) {
+		t.Errorf("mode should be encoded by name, but %s", data)
+	}
+	var decoded MepReport
+	if err = json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Time.Equal(report.Time) {
+		t.Errorf("time should round trip, but %v", decoded.Time)
+	}
+	decoded.Time = report.Time
+	if !reflect.DeepEqual(decoded, report) {
+		t.Errorf("report should round trip, but\n%+v\n%+v", decoded, report)
+	}
+}
+
+func TestStandardInfoJSON(t *testing.T) {
+	for _, name := range StandardNames() {
+		std, _ := GetStandard(name)
+		info := DescribeStandard(std)
+		data, err := json.Marshal(info)
+		if err != nil {
+			t.Errorf("%s should be encoded, but %v", name, err)
+			continue
+		}
+		var decoded StandardInfo
+		if err = json.Unmarshal(data, &decoded); err != nil {
+			t.Errorf("%s should be decoded, but %v", name, err)
+			continue
+		}
+		if !reflect.DeepEqual(decoded, info) {
+			t.Errorf("%s should round trip, but\n%+v\n%+v", name, decoded, info)
+		}
+	}
+
+	data, _ := json.Marshal(DescribeStandard(EpaStandard))
+	for _, s := range []string{
+		`"name":"epa"`,
+		`"pm25_24h":[{"iaqi_from":0,"iaqi_to":50,"from":0,"to":12}`,
+		`"rounding":{"input_digits":{`,
+		`"output":"half-up"`,
+		`"sanitation":{"mode":"clamp","tolerance":0,"tolerances":{"mg/m³":0.1,"ppb":5,"ppm":0.01,"µg/m³":5}}`,
+		`"beyond_index":"flag"`,
+	} {
+		if !strings.Contains(string(data), s) {
+			t.Errorf("epa JSON should contain %s, but %s", s, data)
+		}
+	}
+}
+
+func TestJSONFloat(t *testing.T) {
+	for _, v := range []float64{math.Inf(1), math.Inf(-1), 1.5} {
+		data, err := json.Marshal(jsonFloat(v))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decoded jsonFloat
+		if err = json.Unmarshal(data, &decoded); err != nil || float64(decoded) != v {
+			t.Errorf("%v should round trip, but %s %v %v", v, data, decoded, err)
+		}
+	}
+	if _, err := (RoundingMode(9)).MarshalText(); err == nil {
+		t.Error("unknown rounding mode should fail with error")
+	}
+}
@@ -2,6 +2,7 @@ package aqi
 
 import (
 	"errors"
+	"math"
 	"reflect"
 )
 
@@ -24,16 +25,98 @@ type MepPollutant struct {
 }
 
 type MEPBreakPoint struct {
-	From, To float64
+	From float64 `json:"from"`
+	To   float64 `json:"to"`
 }
 
 var (
 	mepIAQIs          []MEPBreakPoint
 	mepColors         []MepColor
+	mepCategories     []Category
 	mepConcentrations map[string][]MEPBreakPoint
 	mepComputableMaxs map[string]float64
 )
 
+type mepStandard struct {
+	beyondIndex BeyondIndexPolicy
+}
+
+// defaultMep is the MepStandard of the package level functions, it flags
+// concentrations above the top band
+var defaultMep = mepStandard{beyondIndex: BeyondIndexFlag}
+
+// MepStandard is the China MEP AQI standard(HJ633-2012)
+var MepStandard Standard = defaultMep
+
+func (mepStandard) Name() string {
+	return "mep"
+}
+
+func (mepStandard) Pollutants() []string {
+	return sortedKeys(mepComputableMaxs)
+}
+
+func (std mepStandard) IAQI(pollutant string, concentration float64) (int, error) {
+	iaqi, interpolated, err := std.iaqi(pollutant, concentration)
+	if !interpolated {
+		return int(iaqi), err
+	}
+	return MepRoundingPolicy.Index(iaqi), nil
+}
+
+func (mepStandard) AQI(iaqis map[string]int) int {
+	return MaxAggregate(iaqis)
+}
+
+// MepRoundingPolicy keeps concentrations and rounds indexes to one decimal
+// half-up, then up to an integer
+var MepRoundingPolicy = RoundingPolicy{IndexDigits: 1, Output: RoundCeil}
+
+func (std mepStandard) FloatIAQI(pollutant string, concentration float64) (float64, error) {
+	iaqi, _, err := std.iaqi(pollutant, concentration)
+	return iaqi, err
+}
+
+func (mepStandard) RoundingPolicy() RoundingPolicy {
+	return MepRoundingPolicy
+}
+
+func (std mepStandard) BeyondIndexPolicy() BeyondIndexPolicy {
+	return std.beyondIndex
+}
+
+// MepSanitationPolicy sanitizes concentrations before indexing
+var MepSanitationPolicy = DefaultSanitationPolicy
+
+func (mepStandard) SanitationPolicy() SanitationPolicy {
+	return MepSanitationPolicy
+}
+
+func (mepStandard) Unit(pollutant string) (string, error) {
+	if !mepPollutantCalculable(pollutant) {
+		return "", errors.New("Invalid pollutant metric")
+	}
+	return unitOf(ugm3Units, pollutant)
+}
+
+func (mepStandard) BreakPoints(pollutant string) ([]Band, error) {
+	return breakPointBands(mepConcentrations, mepIAQIs, pollutant)
+}
+
+func (mepStandard) Categories() []Category {
+	return mepCategories
+}
+
+// ResponsiblePollutants returns the primary pollutants, which are the ones
+// with the max IAQI when it's above MepPrimaryPollutantClassified or rejected
+// beyond the index
+func (mepStandard) ResponsiblePollutants(iaqis map[string]int) []string {
+	if max := MaxAggregate(iaqis); max != BeyondIndexRejected && max <= MepPrimaryPollutantClassified {
+		return make([]string, 0)
+	}
+	return maxPollutants(iaqis)
+}
+
 // initliaze all mep official suggests colors
 func init() {
 	mepColors = make([]MepColor, 0)
@@ -80,6 +163,31 @@ func init() {
 			},
 		})
 
+	mepCategories = []Category{
+		Category{Level: 1, Name: "Excellent", From: 0, To: 50,
+			Description: "Air quality is satisfactory and basically free of air pollution.",
+			Advice:      "All groups can carry on normal activities."},
+		Category{Level: 2, Name: "Good", From: 51, To: 100,
+			Description: "Air quality is acceptable, but some pollutants may have a weak impact on a very small number of unusually sensitive people.",
+			Advice:      "A very small number of unusually sensitive people should reduce outdoor activities."},
+		Category{Level: 3, Name: "Lightly Polluted", From: 101, To: 150,
+			Description: "Symptoms of susceptible people are slightly aggravated, healthy people show irritation symptoms.",
+			Advice:      "Children, the elderly and patients with heart or respiratory diseases should reduce long and intense outdoor exercise."},
+		Category{Level: 4, Name: "Moderately Polluted", From: 151, To: 200,
+			Description: "Symptoms of susceptible people are further aggravated, hearts and respiratory systems of healthy people may be affected.",
+			Advice:      "Children, the elderly and patients with heart or respiratory diseases should avoid long and intense outdoor exercise, general population should moderately reduce outdoor activities."},
+		Category{Level: 5, Name: "Heavily Polluted", From: 201, To: 300,
+			Description: "Symptoms of patients with heart or lung diseases are significantly aggravated, exercise tolerance is reduced, healthy people commonly show symptoms.",
+			Advice:      "Children, the elderly and patients with heart or lung diseases should stay indoors and stop outdoor exercise, general population should reduce outdoor activities."},
+		Category{Level: 6, Name: "Severely Polluted", From: 301, To: 500,
+			Description: "Exercise tolerance of healthy people is reduced with obvious strong symptoms, some diseases appear in advance.",
+			Advice:      "Children, the elderly and the sick should stay indoors and avoid physical exertion, general population should avoid outdoor activities."},
+	}
+	for i := range mepCategories {
+		mepCategories[i].ColorName = mepColors[i].Name
+		mepCategories[i].Color = mepColors[i].Color
+	}
+
 	mepIAQIs = []MEPBreakPoint{
 		//0, 50, 100, 150, 200, 300, 400, 500
 		MEPBreakPoint{0, 50},
@@ -107,8 +215,7 @@ func init() {
 
 	mepConcentrations["so2_1h"] = []MEPBreakPoint{
 		//0, 150, 500, 650, 800
-		MEPBreakPoint{0, 50},
-		MEPBreakPoint{51, 150},
+		MEPBreakPoint{0, 150},
 		MEPBreakPoint{151, 500},
 		MEPBreakPoint{501, 650},
 		MEPBreakPoint{651, 800},
@@ -196,49 +303,50 @@ func init() {
 	for k, v := range mepConcentrations {
 		mepComputableMaxs[k] = v[len(v)-1].To
 	}
+
+	RegisterStandard(MepStandard)
 }
 
 func mepPollutantCalculable(pollutant string) bool {
 	return mepComputableMaxs[pollutant] > 0
 }
 
-func GetMepIAQI(pollutant string, concentration float64) (int, error) {
-	if concentration == 0 {
-		return 0, nil
+// iaqi returns the unrounded IAQI, interpolated is false for the sentinel
+// values of IAQI
+func (std mepStandard) iaqi(pollutant string, concentration float64) (iaqi float64, interpolated bool, err error) {
+	unit, _ := std.Unit(pollutant)
+	if concentration, iaqi, err = MepSanitationPolicy.apply(concentration, unit); err != nil || concentration == 0 {
+		return iaqi, false, err
 	}
 	if !mepPollutantCalculable(pollutant) {
-		return -1, errors.New("Invalid pollutant metric")
+		return -1, false, errors.New("Invalid pollutant metric")
 	} else {
 		if concentration > mepComputableMaxs[pollutant] {
 			switch pollutant {
 			case "so2_1h", "o3_8h":
-				return -2, errors.New("Concentration value out of range")
+				return -2, false, errors.New("Concentration value out of range")
 			default:
-				return 911, nil
+				return std.beyondIndex.apply(concentration, topBand(std, pollutant))
 			}
 		} else {
-			var bpLow, bpHigh, iaqiLow, iaqiHigh float64
-			for i, point := range mepConcentrations[pollutant] {
-				if concentration >= point.From && concentration <= point.To {
-					bpLow = point.From
-					bpHigh = point.To
-					iaqiLow = mepIAQIs[i].From
-					iaqiHigh = mepIAQIs[i].To
-					break
-				}
-			}
-			if (bpHigh - bpLow) == 0 {
-				return -3, errors.New("Divided by 0???")
-			}
-			roundValue := Round(((iaqiHigh-iaqiLow)/(bpHigh-bpLow))*(concentration-bpLow)+iaqiLow, 1)
-			intValue := int(roundValue)
-			if roundValue*10 > float64(intValue*10) {
-				intValue += 1
+			bands, _ := std.BreakPoints(pollutant)
+			band, v, ok := lookupBand(bands, concentration)
+			if !ok {
+				// below the lowest band
+				return -2, false, errors.New("Concentration value out of range")
 			}
-			return intValue, nil
+			return band.interpolate(v)
 		}
 	}
-	return 0, nil
+}
+
+func GetMepIAQI(pollutant string, concentration float64) (int, error) {
+	return defaultMep.IAQI(pollutant, concentration)
+}
+
+// GetMepIAQIFloat returns the IAQI of GetMepIAQI before rounding
+func GetMepIAQIFloat(pollutant string, concentration float64) (float64, error) {
+	return defaultMep.FloatIAQI(pollutant, concentration)
 }
 
 func GetMepPM25IAQI(concentration float64) (int, error) {
@@ -249,6 +357,9 @@ func GetMepPM10IAQI(concentration float64) (int, error) {
 	return GetMepIAQI("pm10_24h", concentration)
 }
 
+// GetAllIAQI indexes the fields by the default BeyondIndexFlag of
+// MepStandard, pass a standard of WithBeyondIndexPolicy to GetAllIAQI for
+// another policy. The hourly and daily IAQIs use the default too
 func (mep *MepPollutant) GetAllIAQI() map[string]int {
 	var result map[string]int
 	result = make(map[string]int)
@@ -269,18 +380,76 @@ func (mep *MepPollutant) GetAllIAQI() map[string]int {
 	return result
 }
 
-func (mep *MepPollutant) GetAQI() int {
-	var result int
-	result = -1
-	allIAQI := mep.GetAllIAQI()
-	for _, v := range allIAQI {
-		if v >= result {
-			result = v
+// GetAllFloatIAQI returns the unrounded IAQIs of GetAllIAQI
+func (mep *MepPollutant) GetAllFloatIAQI() map[string]float64 {
+	result := make(map[string]float64)
+	for tag, v := range structConcentrations(mep) {
+		if mepPollutantCalculable(tag) {
+			result[tag], _ = GetMepIAQIFloat(tag, v)
 		}
 	}
 	return result
 }
 
+// GetFloatAQI returns the max unrounded IAQI
+func (mep *MepPollutant) GetFloatAQI() float64 {
+	result := -1.0
+	for _, v := range mep.GetAllFloatIAQI() {
+		result = math.Max(result, v)
+	}
+	return result
+}
+
+// GetAQI returns the AQI of GetAllIAQI, by the default policy as well
+func (mep *MepPollutant) GetAQI() int {
+	return MepStandard.AQI(mep.GetAllIAQI())
+}
+
+// pollutants of the hourly and daily AQI of HJ633-2012, the PM fields of the
+// hourly AQI hold 24h sliding averages
+var (
+	mepHourlyPollutants = []string{"so2_1h", "no2_1h", "co_1h", "o3_1h", "pm10_24h", "pm25_24h"}
+	mepDailyPollutants  = []string{"so2_24h", "no2_24h", "co_24h", "o3_1h", "o3_8h", "pm10_24h", "pm25_24h"}
+)
+
+func modeIAQI(all map[string]int, pollutants []string) map[string]int {
+	result := make(map[string]int)
+	for _, tag := range pollutants {
+		result[tag] = all[tag]
+	}
+	return result
+}
+
+// GetHourlyIAQI returns the IAQIs of the hourly AQI, so2_1h over the
+// calculable max is replaced by so2_24h
+func (mep *MepPollutant) GetHourlyIAQI() map[string]int {
+	all := mep.GetAllIAQI()
+	result := modeIAQI(all, mepHourlyPollutants)
+	if result["so2_1h"] == -2 {
+		delete(result, "so2_1h")
+		result["so2_24h"] = all["so2_24h"]
+	}
+	return result
+}
+
+// GetDailyIAQI returns the IAQIs of the daily AQI, o3_8h over the
+// calculable max is reported by o3_1h
+func (mep *MepPollutant) GetDailyIAQI() map[string]int {
+	result := modeIAQI(mep.GetAllIAQI(), mepDailyPollutants)
+	if result["o3_8h"] == -2 {
+		delete(result, "o3_8h")
+	}
+	return result
+}
+
+func (mep *MepPollutant) GetHourlyAQI() int {
+	return MepStandard.AQI(mep.GetHourlyIAQI())
+}
+
+func (mep *MepPollutant) GetDailyAQI() int {
+	return MepStandard.AQI(mep.GetDailyIAQI())
+}
+
 func (mep *MepPollutant) ResponsiblePollutants() []string {
 	var result []string
 	var max int
@@ -302,6 +471,9 @@ func (mep *MepPollutant) ResponsiblePollutants() []string {
 	return result
 }
 
+// NonAttainmentPollutants returns pollutants whose IAQI above 100, which is
+// a proxy only, see ExceededLimits and AssessMepAttainment for GB3095-2012
+// concentration limits
 func (mep *MepPollutant) NonAttainmentPollutants() []string {
 	var result []string
 	result = make([]string, 0)
@@ -1,6 +1,7 @@
 package aqi
 
 import (
+	"math"
 	"testing"
 )
 
@@ -83,8 +84,8 @@ func TestGetMepIAQI(t *testing.T) {
 			if err != nil {
 				t.Errorf("%s with max %f + 1(%f) should not raise exception", v, max, overFlow)
 			}
-			if iaqi != 911 {
-				t.Errorf("%s with max %f + 1(%f) should return 911", v, max, overFlow)
+			if iaqi != BeyondIndex {
+				t.Errorf("%s with max %f + 1(%f) should return BeyondIndex", v, max, overFlow)
 			}
 		}
 	} // end for
@@ -96,6 +97,14 @@ func TestGetMepIAQI(t *testing.T) {
 			t.Errorf("pm25_24h with %f concentration should equal to %d, actually %d", seed, iaqi, v)
 		}
 	}
+	// so2_1h break points 150, 500, 650, 800 of HJ 633-2012 table 1
+	seeds = []float64{150, 500, 650, 800}
+	for i, seed := range seeds {
+		iaqi := int(mepIAQIs[i].To)
+		if v, _ := GetMepIAQI("so2_1h", seed); v != iaqi {
+			t.Errorf("so2_1h with %f concentration should equal to %d, actually %d", seed, iaqi, v)
+		}
+	}
 	// pm25.in sample data
 	if v, _ := GetMepIAQI("pm25_24h", 64); v != 87 {
 		t.Errorf("wanted %d, err %d", 87, v)
@@ -119,7 +128,7 @@ func TestMepPollutantGetAllIAQI(t *testing.T) {
 	nonZeroPollutants := []string{"so2_24h", "no2_24h", "o3_8h", "pm10_24h", "pm25_24h", "co_24h", "o3_1h"}
 	for _, v := range nonZeroPollutants {
 		if result[v] <= 0 {
-			t.Errorf("want > 0 actually %f", result[v])
+			t.Errorf("want > 0 actually %d", result[v])
 		}
 	}
 }
@@ -215,3 +224,107 @@ func TestMepNonAttainmentPollutants(t *testing.T) {
 		t.Errorf("length of result should be 2")
 	}
 }
+
+func TestMepHourlyAQI(t *testing.T) {
+	type Seed struct {
+		Pollutant MepPollutant
+		IAQIs     map[string]int
+		AQI       int
+	}
+	// IAQIs are worked by hand from table 1 and formula 1 of HJ 633-2012,
+	// IAQI = (IAQIHi-IAQILo)/(BPHi-BPLo)·(C-BPLo)+IAQILo rounded up:
+	// so2_1h 30: 50/150·30 = 10, no2_1h 80: 50/100·80 = 40,
+	// co_1h 1.2: 50/5·1.2 = 12, o3_1h 50: 50/160·50 = 15.6 → 16,
+	// pm10_24h 113: 50+50/100·63 = 81.5 → 82,
+	// pm25_24h 82: 100+50/40·7 = 108.75 → 109,
+	// so2_24h 47: 50/50·47 = 47, pm25_24h 30: 50/35·30 = 42.9 → 43
+	seeds := []Seed{
+		// daily fields are ignored by the hourly AQI
+		{MepPollutant{SO2Pollutant1H: 30, NO2Pollutant1H: 80, COPollutant1H: 1.2, O3Pollutant1H: 50,
+			PM10Pollutant24H: 113, PM25Pollutant24H: 82, SO2Pollutant24H: 200, O3Pollutant8H: 300},
+			map[string]int{"so2_1h": 10, "no2_1h": 40, "co_1h": 12, "o3_1h": 16, "pm10_24h": 82, "pm25_24h": 109}, 109},
+		// so2_1h over 800 is replaced by so2_24h
+		{MepPollutant{SO2Pollutant1H: 900, SO2Pollutant24H: 47, PM25Pollutant24H: 30},
+			map[string]int{"so2_24h": 47, "no2_1h": 0, "co_1h": 0, "o3_1h": 0, "pm10_24h": 0, "pm25_24h": 43}, 47},
+	}
+	for _, seed := range seeds {
+		iaqis := seed.Pollutant.GetHourlyIAQI()
+		if len(iaqis) != len(seed.IAQIs) {
+			t.Errorf("hourly IAQIs should be %v, but %v", seed.IAQIs, iaqis)
+		}
+		for tag, v := range seed.IAQIs {
+			if iaqi, ok := iaqis[tag]; !ok || iaqi != v {
+				t.Errorf("hourly %s IAQI should be %d, but %d", tag, v, iaqi)
+			}
+		}
+		if aqi := seed.Pollutant.GetHourlyAQI(); aqi != seed.AQI {
+			t.Errorf("hourly AQI should be %d, but %d", seed.AQI, aqi)
+		}
+	}
+	if aqi := seeds[0].Pollutant.GetAQI(); aqi <= seeds[0].AQI {
+		t.Errorf("AQI of all fields should mix daily fields, but %d", aqi)
+	}
+}
+
+func TestMepDailyAQI(t *testing.T) {
+	type Seed struct {
+		Pollutant MepPollutant
+		IAQIs     map[string]int
+		AQI       int
+	}
+	// IAQIs are worked by hand from table 1 and formula 1 of HJ 633-2012:
+	// so2_24h 10: 50/50·10 = 10, no2_24h 20: 50/40·20 = 25,
+	// co_24h 1: 50/2·1 = 25, o3_1h 115: 50/160·115 = 35.9 → 36,
+	// o3_8h 98: 50/100·98 = 49, pm10_24h 113 → 82, pm25_24h 82 → 109,
+	// o3_1h 1000: 300+100/200·200 = 400
+	seeds := []Seed{
+		// hourly gases are ignored by the daily AQI
+		{MepPollutant{SO2Pollutant24H: 10, NO2Pollutant24H: 20, COPollutant24H: 1, O3Pollutant1H: 115, O3Pollutant8H: 98,
+			PM10Pollutant24H: 113, PM25Pollutant24H: 82, SO2Pollutant1H: 600, NO2Pollutant1H: 1000},
+			map[string]int{"so2_24h": 10, "no2_24h": 25, "co_24h": 25, "o3_1h": 36, "o3_8h": 49, "pm10_24h": 82, "pm25_24h": 109}, 109},
+		// o3_8h over 800 is reported by o3_1h
+		{MepPollutant{O3Pollutant1H: 1000, O3Pollutant8H: 900},
+			map[string]int{"so2_24h": 0, "no2_24h": 0, "co_24h": 0, "o3_1h": 400, "pm10_24h": 0, "pm25_24h": 0}, 400},
+	}
+	for _, seed := range seeds {
+		iaqis := seed.Pollutant.GetDailyIAQI()
+		if len(iaqis) != len(seed.IAQIs) {
+			t.Errorf("daily IAQIs should be %v, but %v", seed.IAQIs, iaqis)
+		}
+		for tag, v := range seed.IAQIs {
+			if iaqi, ok := iaqis[tag]; !ok || iaqi != v {
+				t.Errorf("daily %s IAQI should be %d, but %d", tag, v, iaqi)
+			}
+		}
+		if aqi := seed.Pollutant.GetDailyAQI(); aqi != seed.AQI {
+			t.Errorf("daily AQI should be %d, but %d", seed.AQI, aqi)
+		}
+	}
+}
+
+func TestGetMepIAQIFloat(t *testing.T) {
+	type Seed struct {
+		Tag           string
+		Concentration float64
+		IAQI          float64
+	}
+	seeds := []Seed{
+		{"pm25_24h", 82, 108.538},
+		{"pm10_24h", 113, 81.687},
+		{"pm25_24h", 0, 0},
+	}
+	for _, seed := range seeds {
+		if v, err := GetMepIAQIFloat(seed.Tag, seed.Concentration); err != nil || math.Abs(v-seed.IAQI) > 1e-3 {
+			t.Errorf("%s %v float IAQI should be %v, but %v %v", seed.Tag, seed.Concentration, seed.IAQI, v, err)
+		}
+	}
+
+	mep := &MepPollutant{PM25Pollutant24H: 82, PM10Pollutant24H: 113}
+	iaqis := mep.GetAllFloatIAQI()
+	if len(iaqis) != len(validMEPPollutants) || iaqis["pm10_24h"] >= iaqis["pm25_24h"] {
+		t.Errorf("float IAQIs should cover every pollutant, but %v", iaqis)
+	}
+	if aqi := mep.GetFloatAQI(); aqi != iaqis["pm25_24h"] || int(math.Ceil(aqi)) != mep.GetAQI() {
+		t.Errorf("float AQI should be pm25_24h %v, but %v", iaqis["pm25_24h"], aqi)
+	}
+}
@@ -0,0 +1,361 @@
+package aqi
+
+import (
+	"errors"
+	"math"
+)
+
+const (
+	// weight factor floor of the 12-hr weighted PM average
+	MexPMMinWeight = 0.5
+	MexPMHours     = 12
+)
+
+// Mexico Índice AIRE y SALUD(NOM-172-SEMARNAT-2019)
+type MexPollutant struct {
+	O3Pollutant1H    float64 `json:"o3_1h" truncate:"3"`    // ppm
+	O3Pollutant8H    float64 `json:"o3_8h" truncate:"3"`    // ppm, 8-hr moving average
+	NO2Pollutant1H   float64 `json:"no2_1h" truncate:"3"`   // ppm
+	SO2Pollutant24H  float64 `json:"so2_24h" truncate:"3"`  // ppm, 24-hr moving average
+	COPollutant8H    float64 `json:"co_8h" truncate:"2"`    // ppm, 8-hr moving average
+	PM10Pollutant12H float64 `json:"pm10_12h" truncate:"0"` // µg/m³, 12-hr weighted average
+	PM25Pollutant12H float64 `json:"pm25_12h" truncate:"0"` // µg/m³, 12-hr weighted average
+}
+
+type MEXBreakPoint struct {
+	From, To float64
+}
+
+// MexCategory is an Índice AIRE y SALUD band with its risk level and
+// health message
+type MexCategory struct {
+	Level   int
+	Name    string
+	Risk    string
+	Message string
+	Color   MexColor
+}
+
+var (
+	mexIAQIs          []MEXBreakPoint
+	mexColors         []MexColor
+	mexCategories     []MexCategory
+	mexConcentrations map[string][]MEXBreakPoint
+	mexComputableMaxs map[string]float64
+	mexTruncateRules  map[string]int
+)
+
+type mexStandard struct {
+	beyondIndex BeyondIndexPolicy
+}
+
+// defaultMex is the MexStandard of the package level functions, it
+// extrapolates concentrations above the top band
+var defaultMex = mexStandard{beyondIndex: BeyondIndexExtrapolate}
+
+// MexStandard is the Mexico Índice AIRE y SALUD standard
+var MexStandard Standard = defaultMex
+
+func (mexStandard) Name() string {
+	return "mex"
+}
+
+func (mexStandard) Pollutants() []string {
+	return sortedKeys(mexComputableMaxs)
+}
+
+func (std mexStandard) IAQI(pollutant string, concentration float64) (int, error) {
+	iaqi, interpolated, err := std.iaqi(pollutant, concentration)
+	if !interpolated {
+		return int(iaqi), err
+	}
+	return MexRoundingPolicy.Index(iaqi), nil
+}
+
+func (mexStandard) AQI(iaqis map[string]int) int {
+	return MaxAggregate(iaqis)
+}
+
+// MexRoundingPolicy truncates concentrations by the `truncate` struct tags
+// of MexPollutant and rounds indexes half-up
+var MexRoundingPolicy RoundingPolicy
+
+func (std mexStandard) FloatIAQI(pollutant string, concentration float64) (float64, error) {
+	iaqi, _, err := std.iaqi(pollutant, concentration)
+	return iaqi, err
+}
+
+func (mexStandard) RoundingPolicy() RoundingPolicy {
+	return MexRoundingPolicy
+}
+
+func (std mexStandard) BeyondIndexPolicy() BeyondIndexPolicy {
+	return std.beyondIndex
+}
+
+// MexSanitationPolicy sanitizes concentrations before indexing
+var MexSanitationPolicy = DefaultSanitationPolicy
+
+func (mexStandard) SanitationPolicy() SanitationPolicy {
+	return MexSanitationPolicy
+}
+
+func (mexStandard) Unit(pollutant string) (string, error) {
+	if !mexPollutantCalculable(pollutant) {
+		return "", errors.New("Invalid pollutant metric")
+	}
+	return unitOf(ppmUnits, pollutant)
+}
+
+func (mexStandard) BreakPoints(pollutant string) ([]Band, error) {
+	return breakPointBands(mexConcentrations, mexIAQIs, pollutant)
+}
+
+func (mexStandard) Categories() []Category {
+	result := make([]Category, 0, len(mexCategories))
+	for i, category := range mexCategories {
+		from, to := 0, 0
+		if i < len(mexIAQIs) {
+			from, to = int(mexIAQIs[i].From), int(mexIAQIs[i].To)
+		} else {
+			from, to = int(mexIAQIs[i-1].To)+1, MaxIndex
+		}
+		result = append(result, Category{
+			Level:       category.Level,
+			Name:        category.Name,
+			From:        from,
+			To:          to,
+			ColorName:   category.Color.Name,
+			Color:       category.Color.Color,
+			Description: "Riesgo " + category.Risk,
+			Advice:      category.Message,
+		})
+	}
+	return result
+}
+
+// initliaze NOM-172 colors, categories and break points
+func init() {
+	mexColors = []MexColor{
+		MexColor{Name: "VERDE", Color: Color{R: 0, G: 228, B: 0, C: 40, M: 0, Y: 100, K: 0}},
+		MexColor{Name: "AMARILLO", Color: Color{R: 255, G: 255, B: 0, C: 0, M: 0, Y: 100, K: 0}},
+		MexColor{Name: "NARANJA", Color: Color{R: 255, G: 126, B: 0, C: 0, M: 52, Y: 100, K: 0}},
+		MexColor{Name: "ROJO", Color: Color{R: 255, G: 0, B: 0, C: 0, M: 100, Y: 100, K: 0}},
+		MexColor{Name: "MORADO", Color: Color{R: 143, G: 63, B: 151, C: 5, M: 58, Y: 0, K: 41}},
+	}
+
+	mexCategories = []MexCategory{
+		MexCategory{1, "Buena", "Bajo",
+			"Disfruta las actividades al aire libre.", mexColors[0]},
+		MexCategory{2, "Aceptable", "Moderado",
+			"Las personas sensibles deben considerar limitar las actividades vigorosas al aire libre.", mexColors[1]},
+		MexCategory{3, "Mala", "Alto",
+			"Evita las actividades vigorosas al aire libre, las personas sensibles deben permanecer en interiores.", mexColors[2]},
+		MexCategory{4, "Muy Mala", "Muy alto",
+			"Evita las actividades al aire libre, la población en general debe permanecer en interiores.", mexColors[3]},
+		MexCategory{5, "Extremadamente Mala", "Extremadamente alto",
+			"Permanece en interiores y sigue las indicaciones de las autoridades.", mexColors[4]},
+	}
+
+	// the fifth band has no upper limit, it extends the fourth band's slope
+	mexIAQIs = []MEXBreakPoint{
+		//0, 50, 100, 150, 200
+		MEXBreakPoint{0, 50},
+		MEXBreakPoint{51, 100},
+		MEXBreakPoint{101, 150},
+		MEXBreakPoint{151, 200},
+	}
+
+	mexConcentrations = make(map[string][]MEXBreakPoint)
+	mexComputableMaxs = make(map[string]float64)
+
+	mexConcentrations["o3_1h"] = []MEXBreakPoint{
+		//0, 0.051, 0.095, 0.135, 0.175
+		MEXBreakPoint{0.000, 0.051},
+		MEXBreakPoint{0.052, 0.095},
+		MEXBreakPoint{0.096, 0.135},
+		MEXBreakPoint{0.136, 0.175},
+	}
+
+	mexConcentrations["o3_8h"] = []MEXBreakPoint{
+		//0, 0.051, 0.070, 0.092, 0.114
+		MEXBreakPoint{0.000, 0.051},
+		MEXBreakPoint{0.052, 0.070},
+		MEXBreakPoint{0.071, 0.092},
+		MEXBreakPoint{0.093, 0.114},
+	}
+
+	mexConcentrations["no2_1h"] = []MEXBreakPoint{
+		//0, 0.107, 0.210, 0.230, 0.250
+		MEXBreakPoint{0.000, 0.107},
+		MEXBreakPoint{0.108, 0.210},
+		MEXBreakPoint{0.211, 0.230},
+		MEXBreakPoint{0.231, 0.250},
+	}
+
+	mexConcentrations["so2_24h"] = []MEXBreakPoint{
+		//0, 0.008, 0.110, 0.165, 0.220
+		MEXBreakPoint{0.000, 0.008},
+		MEXBreakPoint{0.009, 0.110},
+		MEXBreakPoint{0.111, 0.165},
+		MEXBreakPoint{0.166, 0.220},
+	}
+
+	mexConcentrations["co_8h"] = []MEXBreakPoint{
+		//0, 8.75, 11.00, 13.30, 15.50
+		MEXBreakPoint{0.00, 8.75},
+		MEXBreakPoint{8.76, 11.00},
+		MEXBreakPoint{11.01, 13.30},
+		MEXBreakPoint{13.31, 15.50},
+	}
+
+	mexConcentrations["pm10_12h"] = []MEXBreakPoint{
+		//0, 50, 75, 155, 235
+		MEXBreakPoint{0, 50},
+		MEXBreakPoint{51, 75},
+		MEXBreakPoint{76, 155},
+		MEXBreakPoint{156, 235},
+	}
+
+	mexConcentrations["pm25_12h"] = []MEXBreakPoint{
+		//0, 25, 45, 79, 147
+		MEXBreakPoint{0, 25},
+		MEXBreakPoint{26, 45},
+		MEXBreakPoint{46, 79},
+		MEXBreakPoint{80, 147},
+	}
+
+	for k, v := range mexConcentrations {
+		mexComputableMaxs[k] = v[len(v)-1].To
+	}
+
+	mexTruncateRules = truncateRules(&MexPollutant{}, mexPollutantCalculable)
+	MexRoundingPolicy = RoundingPolicy{InputDigits: mexTruncateRules, Output: RoundHalfUp}
+
+	RegisterStandard(MexStandard)
+}
+
+func mexPollutantCalculable(pollutant string) bool {
+	return mexComputableMaxs[pollutant] > 0
+}
+
+// iaqi returns the unrounded IAQI, interpolated is false for the sentinel
+// values of IAQI
+func (std mexStandard) iaqi(pollutant string, concentration float64) (iaqi float64, interpolated bool, err error) {
+	unit, _ := std.Unit(pollutant)
+	if concentration, iaqi, err = MexSanitationPolicy.apply(concentration, unit); err != nil || concentration == 0 {
+		return iaqi, false, err
+	}
+	if !mexPollutantCalculable(pollutant) {
+		return -1, false, errors.New("Invalid pollutant metric")
+	}
+	concentration = MexRoundingPolicy.Input(pollutant, concentration)
+	if concentration > mexComputableMaxs[pollutant] {
+		return std.beyondIndex.apply(concentration, topBand(std, pollutant))
+	}
+	bands, _ := std.BreakPoints(pollutant)
+	band, v, ok := lookupBand(bands, concentration)
+	if !ok {
+		// below the lowest band
+		return -2, false, errors.New("Concentration value out of range")
+	}
+	return band.interpolate(v)
+}
+
+// GetMexIAQI returns the index of a pollutant, concentrations above the
+// "Muy Mala" band continue with its slope into "Extremadamente Mala"
+func GetMexIAQI(pollutant string, concentration float64) (int, error) {
+	return defaultMex.IAQI(pollutant, concentration)
+}
+
+// GetMexIAQIFloat returns the IAQI of GetMexIAQI before rounding
+func GetMexIAQIFloat(pollutant string, concentration float64) (float64, error) {
+	return defaultMex.FloatIAQI(pollutant, concentration)
+}
+
+func GetMexPM25IAQI(concentration float64) (int, error) {
+	return GetMexIAQI("pm25_12h", concentration)
+}
+
+func GetMexPM10IAQI(concentration float64) (int, error) {
+	return GetMexIAQI("pm10_12h", concentration)
+}
+
+// GetMexCategory returns the band of an index value
+func GetMexCategory(iaqi int) MexCategory {
+	for i, point := range mexIAQIs {
+		if float64(iaqi) <= point.To {
+			return mexCategories[i]
+		}
+	}
+	return mexCategories[len(mexCategories)-1]
+}
+
+// GetMexPMWeightedAverage returns the NOM-172 12-hr weighted moving average of
+// hourly PM concentrations ordered from the most recent hour, missing hours
+// are NaN or negative. Two of the three most recent hours must be valid.
+func GetMexPMWeightedAverage(hourly []float64) (float64, error) {
+	if len(hourly) > MexPMHours {
+		hourly = hourly[:MexPMHours]
+	}
+	valid := func(v float64) bool {
+		return !math.IsNaN(v) && v >= 0
+	}
+	recent := 0
+	for i := 0; i < len(hourly) && i < 3; i++ {
+		if valid(hourly[i]) {
+			recent++
+		}
+	}
+	if recent < 2 {
+		return 0, errors.New("Insufficient recent hours")
+	}
+	min, max := math.MaxFloat64, 0.0
+	for _, v := range hourly {
+		if valid(v) {
+			min = math.Min(min, v)
+			max = math.Max(max, v)
+		}
+	}
+	weight := 1.0
+	if max > 0 {
+		weight = math.Max(min/max, MexPMMinWeight)
+	}
+	var sum, weights float64
+	for i, v := range hourly {
+		if valid(v) {
+			w := math.Pow(weight, float64(i))
+			sum += w * v
+			weights += w
+		}
+	}
+	return sum / weights, nil
+}
+
+// GetAllIAQI is GetAllIAQI of MexStandard, which keeps the default
+// BeyondIndexExtrapolate whatever WithBeyondIndexPolicy returns
+func (mex *MexPollutant) GetAllIAQI() map[string]int {
+	return GetAllIAQI(MexStandard, mex)
+}
+
+func (mex *MexPollutant) GetAQI() int {
+	return MexStandard.AQI(mex.GetAllIAQI())
+}
+
+func (mex *MexPollutant) ResponsiblePollutants() []string {
+	return maxPollutants(mex.GetAllIAQI())
+}
+
+// Category returns the band of the composite index
+func (mex *MexPollutant) Category() MexCategory {
+	return GetMexCategory(mex.GetAQI())
+}
+
+// RiskLevels returns the band of every pollutant
+func (mex *MexPollutant) RiskLevels() map[string]MexCategory {
+	result := make(map[string]MexCategory)
+	for k, v := range mex.GetAllIAQI() {
+		result[k] = GetMexCategory(v)
+	}
+	return result
+}
@@ -0,0 +1,103 @@
+package aqi
+
+import (
+	"math"
+	"testing"
+)
+
+var (
+	validMEXPollutants = []string{"o3_1h", "o3_8h", "no2_1h", "so2_24h", "co_8h", "pm10_12h", "pm25_12h"}
+)
+
+func TestMexPollutantCalculable(t *testing.T) {
+	for _, v := range validMEXPollutants {
+		if !mexPollutantCalculable(v) {
+			t.Errorf("%s should be calculable", v)
+		}
+	}
+	for _, v := range []string{"foo", "pm25_24h"} {
+		if mexPollutantCalculable(v) {
+			t.Errorf("%s should not be calculable", v)
+		}
+	}
+}
+
+func TestGetMexIAQI(t *testing.T) {
+	iaqi, err := GetMexIAQI("foo", 42)
+	if err == nil {
+		t.Error("fake foo pollutant should raise exception")
+	}
+	if iaqi != -1 {
+		t.Error("fake foo pollutant with gt 0 value should return -1")
+	}
+
+	type Seed struct {
+		Pollutant     string
+		Concentration float64
+		Expection     int
+	}
+
+	seeds := []Seed{
+		Seed{"pm25_12h", 25, 50}, Seed{"pm25_12h", 45, 100},
+		Seed{"pm10_12h", 155, 150}, Seed{"o3_1h", 0.175, 200},
+		Seed{"co_8h", 8.75, 50}, Seed{"so2_24h", 0.009, 51},
+		// beyond "Muy Mala" extends the last slope
+		Seed{"pm25_12h", 214, 249}, Seed{"no2_1h", 0.269, 249},
+	}
+
+	for _, seed := range seeds {
+		v, _ := GetMexIAQI(seed.Pollutant, seed.Concentration)
+		if v != seed.Expection {
+			t.Errorf("%s with %f should return %d, but %d", seed.Pollutant, seed.Concentration, seed.Expection, v)
+		}
+	}
+}
+
+func TestGetMexCategory(t *testing.T) {
+	seeds := map[int]string{0: "Buena", 50: "Buena", 51: "Aceptable", 150: "Mala", 151: "Muy Mala", 201: "Extremadamente Mala", 911: "Extremadamente Mala"}
+	for iaqi, name := range seeds {
+		if v := GetMexCategory(iaqi); v.Name != name {
+			t.Errorf("%d should be %s, but %s", iaqi, name, v.Name)
+		}
+	}
+}
+
+func TestGetMexPMWeightedAverage(t *testing.T) {
+	v, err := GetMexPMWeightedAverage([]float64{30, 30, 30, 30})
+	if err != nil || v != 30 {
+		t.Errorf("constant hours should average to 30, but %f %v", v, err)
+	}
+	// weight floors at 0.5: (40 + 0.5*10) / 1.5
+	v, _ = GetMexPMWeightedAverage([]float64{40, 10})
+	if math.Abs(v-30) > 1e-9 {
+		t.Errorf("err %f, want 30", v)
+	}
+	// weight 0.8: (50 + 0.8*40) / 1.8
+	v, _ = GetMexPMWeightedAverage([]float64{50, 40, math.NaN()})
+	if math.Abs(v-82.0/1.8) > 1e-9 {
+		t.Errorf("err %f, want %f", v, 82.0/1.8)
+	}
+	if _, err = GetMexPMWeightedAverage([]float64{50, math.NaN(), -1, 20}); err == nil {
+		t.Error("one valid of three recent hours should raise exception")
+	}
+}
+
+func TestMexGetAQI(t *testing.T) {
+	mex := &MexPollutant{
+		O3Pollutant1H:    0.060,
+		PM10Pollutant12H: 80,
+		PM25Pollutant12H: 30,
+	}
+	if v := mex.GetAQI(); v != 103 {
+		t.Errorf("should pass with %d", v)
+	}
+	if v := mex.ResponsiblePollutants(); len(v) != 1 || v[0] != "pm10_12h" {
+		t.Errorf("should be pm10_12h, but %v", v)
+	}
+	if v := mex.Category(); v.Name != "Mala" || v.Color.RGBToHex() != "#FF7E00" {
+		t.Errorf("should be Mala, but %s", v.Name)
+	}
+	if v := mex.RiskLevels()["pm25_12h"]; v.Risk != "Moderado" {
+		t.Errorf("pm25_12h should be Moderado, but %s", v.Risk)
+	}
+}
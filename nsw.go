@@ -0,0 +1,70 @@
+package aqi
+
+import (
+	"math"
+)
+
+// New South Wales Air Quality Category(AQC), percent of the standards of the
+// Ambient Air Quality NEPM(as varied 2021)
+type NswPollutant struct {
+	PM25Pollutant24H float64 `json:"pm25_24h"` // µg/m³
+	PM10Pollutant24H float64 `json:"pm10_24h"` // µg/m³
+	O3Pollutant8H    float64 `json:"o3_8h"`    // ppm
+	NO2Pollutant1H   float64 `json:"no2_1h"`   // ppm
+	SO2Pollutant1H   float64 `json:"so2_1h"`   // ppm
+	COPollutant8H    float64 `json:"co_8h"`    // ppm
+}
+
+// NswStandard is the NSW AQC standard
+var NswStandard *PercentStandard
+
+func init() {
+	NswStandard = NewPercentStandard("nsw",
+		map[string]float64{
+			"pm25_24h": 25,
+			"pm10_24h": 50,
+			"o3_8h":    0.065,
+			"no2_1h":   0.08,
+			"so2_1h":   0.10,
+			"co_8h":    9.0,
+		},
+		[]PercentCategory{
+			PercentCategory{"Very Good", 0, 33},
+			PercentCategory{"Good", 34, 66},
+			PercentCategory{"Fair", 67, 99},
+			PercentCategory{"Poor", 100, 149},
+			PercentCategory{"Very Poor", 150, 199},
+			PercentCategory{"Hazardous", 200, math.MaxFloat64},
+		})
+	NswStandard.SetUnits(map[string]string{
+		"pm25_24h": UnitUgm3,
+		"pm10_24h": UnitUgm3,
+		"o3_8h":    UnitPpm,
+		"no2_1h":   UnitPpm,
+		"so2_1h":   UnitPpm,
+		"co_8h":    UnitPpm,
+	})
+
+	RegisterStandard(NswStandard)
+}
+
+func GetNswIAQI(pollutant string, concentration float64) (int, error) {
+	return NswStandard.IAQI(pollutant, concentration)
+}
+
+func (nsw *NswPollutant) GetAllIAQI() map[string]int {
+	return GetAllIAQI(NswStandard, nsw)
+}
+
+func (nsw *NswPollutant) GetAQI() int {
+	return NswStandard.AQI(nsw.GetAllIAQI())
+}
+
+func (nsw *NswPollutant) ResponsiblePollutants() []string {
+	return maxPollutants(nsw.GetAllIAQI())
+}
+
+// Category returns the air quality category of the composite index
+func (nsw *NswPollutant) Category() (PercentCategory, error) {
+	return NswStandard.Category(nsw.GetAQI())
+}
@@ -0,0 +1,43 @@
+package aqi
+
+import (
+	"testing"
+)
+
+func TestGetNswIAQI(t *testing.T) {
+	if v, _ := GetNswIAQI("pm25_24h", 25); v != 100 {
+		t.Errorf("err %d, want 100", v)
+	}
+	if v, _ := GetNswIAQI("o3_8h", 0.065); v != 100 {
+		t.Errorf("o3_8h 0.065 ppm is the 2021 NEPM standard, err %d, want 100", v)
+	}
+	if _, err := GetNswIAQI("o3_1h", 0.1); err == nil {
+		t.Error("o3_1h was replaced by o3_8h in the 2021 NEPM")
+	}
+	if v, _ := GetNswIAQI("no2_1h", 0.08); v != 100 {
+		t.Errorf("no2_1h 0.08 ppm is the 2021 NEPM standard, err %d, want 100", v)
+	}
+	if v, _ := GetNswIAQI("so2_1h", 0.10); v != 100 {
+		t.Errorf("so2_1h 0.10 ppm is the 2021 NEPM standard, err %d, want 100", v)
+	}
+	if _, err := GetNswIAQI("so2_24h", 0.1); err == nil {
+		t.Error("so2_24h is not a NEPM standard pollutant")
+	}
+}
+
+func TestNswGetAQI(t *testing.T) {
+	nsw := &NswPollutant{
+		PM25Pollutant24H: 20,
+		PM10Pollutant24H: 60,
+		O3Pollutant8H:    0.05,
+	}
+	if v := nsw.GetAQI(); v != 120 {
+		t.Errorf("should pass with %d", v)
+	}
+	if v := nsw.ResponsiblePollutants(); len(v) != 1 || v[0] != "pm10_24h" {
+		t.Errorf("should be pm10_24h, but %v", v)
+	}
+	if v, err := nsw.Category(); err != nil || v.Name != "Poor" {
+		t.Errorf("should be Poor, but %s %v", v.Name, err)
+	}
+}
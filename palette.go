@@ -0,0 +1,126 @@
+package aqi
+
+import (
+	"errors"
+	"math"
+	"strconv"
+)
+
+// Palette is a set of category colors of a standard
+type Palette int
+
+const (
+	// PaletteOfficial is the category colors published by the standard
+	PaletteOfficial Palette = iota
+	// PaletteColorblind is a sequential palette sampled from viridis, told
+	// apart by lightness under every common color vision deficiency
+	PaletteColorblind
+)
+
+func (palette Palette) String() string {
+	switch palette {
+	case PaletteOfficial:
+		return "official"
+	case PaletteColorblind:
+		return "colorblind"
+	}
+	return "Palette(" + strconv.Itoa(int(palette)) + ")"
+}
+
+func (palette Palette) MarshalText() ([]byte, error) {
+	return marshalEnum("palette", int(palette), 2, palette.String())
+}
+
+func (palette *Palette) UnmarshalText(text []byte) error {
+	v, err := unmarshalEnum("palette", text, 2, func(i int) string { return Palette(i).String() })
+	if err == nil {
+		*palette = Palette(v)
+	}
+	return err
+}
+
+// colorblindStops are viridis from its lightest to its darkest color, so the
+// palette darkens as the air worsens
+var colorblindStops = []Color{
+	NewRGBColor(0xFD, 0xE7, 0x25),
+	NewRGBColor(0x5E, 0xC9, 0x62),
+	NewRGBColor(0x21, 0x91, 0x8C),
+	NewRGBColor(0x3B, 0x52, 0x8B),
+	NewRGBColor(0x44, 0x01, 0x54),
+}
+
+// PaletteColors returns the colors of the categories of a standard in a
+// palette, ordered as its categories
+func PaletteColors(std Standard, palette Palette) ([]Color, error) {
+	categorizer, ok := std.(Categorizer)
+	if !ok {
+		return nil, errors.New("Standard " + std.Name() + " has no categories")
+	}
+	categories := categorizer.Categories()
+	if len(categories) == 0 {
+		return nil, errors.New("Standard " + std.Name() + " has no categories")
+	}
+	result := make([]Color, len(categories))
+	switch palette {
+	case PaletteOfficial:
+		for i, category := range categories {
+			if category.ColorName == "" && category.Color == (Color{}) {
+				return nil, errors.New("Standard " + std.Name() + " has no colors")
+			}
+			result[i] = category.Color
+		}
+	case PaletteColorblind:
+		for i := range result {
+			t := 0.0
+			if len(result) > 1 {
+				t = float64(i) / float64(len(result)-1)
+			}
+			result[i] = sampleStops(colorblindStops, t)
+		}
+	default:
+		return nil, errors.New("Invalid palette " + palette.String())
+	}
+	return result, nil
+}
+
+// sampleStops returns the color at t in [0, 1] of evenly spaced stops,
+// interpolated in OKLab
+func sampleStops(stops []Color, t float64) Color {
+	v := clamp01(t) * float64(len(stops)-1)
+	i := int(v)
+	if i >= len(stops)-1 {
+		return stops[len(stops)-1]
+	}
+	return InterpolateColor(stops[i], stops[i+1], v-float64(i))
+}
+
+// ScaleColor returns the continuous color of an index value in a palette.
+// Every category color sits at the middle of its category, or at the From
+// of the one reaching MaxIndex, and the colors in between are interpolated in
+// OKLab
+func ScaleColor(std Standard, aqi float64, palette Palette) (Color, error) {
+	colors, err := PaletteColors(std, palette)
+	if err != nil {
+		return Color{}, err
+	}
+	if aqi < 0 || math.IsNaN(aqi) {
+		return Color{}, errors.New("Invalid index value")
+	}
+	categories := std.(Categorizer).Categories()
+	anchors := make([]float64, len(categories))
+	for i, category := range categories {
+		anchors[i] = float64(category.From)
+		if category.To < MaxIndex {
+			anchors[i] = float64(category.From+category.To) / 2
+		}
+	}
+	if aqi <= anchors[0] {
+		return colors[0], nil
+	}
+	for i := 1; i < len(anchors); i++ {
+		if aqi <= anchors[i] {
+			return InterpolateColor(colors[i-1], colors[i], (aqi-anchors[i-1])/(anchors[i]-anchors[i-1])), nil
+		}
+	}
+	return colors[len(colors)-1], nil
+}
@@ -0,0 +1,80 @@
+package aqi
+
+import (
+	"testing"
+)
+
+func TestPaletteColors(t *testing.T) {
+	colors, err := PaletteColors(EpaStandard, PaletteOfficial)
+	if err != nil || len(colors) != len(epaColors) {
+		t.Fatalf("epa should have %d official colors, but %v %v", len(epaColors), colors, err)
+	}
+	for i := range colors {
+		if colors[i] != epaColors[i].Color {
+			t.Errorf("epa color %d should be %s, but %s", i, epaColors[i].RGBToHex(), colors[i].RGBToHex())
+		}
+	}
+	if _, err := PaletteColors(NswStandard, PaletteOfficial); err == nil {
+		t.Error("nsw has no official colors, should fail with error")
+	}
+	if _, err := PaletteColors(EpaStandard, Palette(9)); err == nil {
+		t.Error("invalid palette should fail with error")
+	}
+	empty := NewPercentStandard("test-empty", map[string]float64{"pm25_24h": 25}, nil)
+	if _, err := PaletteColors(empty, PaletteColorblind); err == nil {
+		t.Error("standard without categories should fail with error")
+	}
+	if _, err := ScaleColor(empty, 42, PaletteColorblind); err == nil {
+		t.Error("standard without categories should fail with error")
+	}
+
+	for _, name := range StandardNames() {
+		std, _ := GetStandard(name)
+		colors, err := PaletteColors(std, PaletteColorblind)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if colors[0].RGBToHex() != "#FDE725" || colors[len(colors)-1].RGBToHex() != "#440154" {
+			t.Errorf("%s colorblind palette should span viridis, but %s %s", name, colors[0].RGBToHex(), colors[len(colors)-1].RGBToHex())
+		}
+		// lightness strictly decreases, so categories stay apart without hue
+		for i := 1; i < len(colors); i++ {
+			l1, _, _ := colors[i-1].OKLab()
+			l2, _, _ := colors[i].OKLab()
+			if l2 >= l1 {
+				t.Errorf("%s colorblind color %d should be darker than %d", name, i, i-1)
+			}
+		}
+	}
+}
+
+func TestScaleColor(t *testing.T) {
+	type Seed struct {
+		AQI      float64
+		Expected string
+	}
+	seeds := []Seed{
+		{0, "#00E400"}, {25, "#00E400"}, {50, "#A9F300"}, {75.5, "#FFFF00"},
+		{250.5, "#99004C"}, {301, "#7E0023"}, {600, "#7E0023"},
+	}
+	for _, seed := range seeds {
+		color, err := ScaleColor(EpaStandard, seed.AQI, PaletteOfficial)
+		if err != nil || color.RGBToHex() != seed.Expected {
+			t.Errorf("epa %v should be %s, but %s %v", seed.AQI, seed.Expected, color.RGBToHex(), err)
+		}
+	}
+	if color, _ := ScaleColor(MepStandard, 25, PaletteColorblind); color.RGBToHex() != "#FDE725" {
+		t.Errorf("mep 25 should be #FDE725, but %s", color.RGBToHex())
+	}
+	if _, err := ScaleColor(EpaStandard, -1, PaletteOfficial); err == nil {
+		t.Error("negative index should fail with error")
+	}
+
+	var palette Palette
+	if err := palette.UnmarshalText([]byte("colorblind")); err != nil || palette != PaletteColorblind {
+		t.Errorf("colorblind should be decoded, but %v %v", palette, err)
+	}
+	if _, err := Palette(2).MarshalText(); err == nil {
+		t.Error("invalid palette should fail with error")
+	}
+}
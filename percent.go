@@ -0,0 +1,159 @@
+package aqi
+
+import (
+	"errors"
+)
+
+// PercentCategory is a band of percent-of-guideline index values
+type PercentCategory struct {
+	Name     string
+	From, To float64
+}
+
+// PercentStandard is an index family whose individual index is the
+// concentration as a percentage of the pollutant's guideline(standard),
+// used by Australian AQC and New Zealand reporting
+type PercentStandard struct {
+	name       string
+	guidelines map[string]float64
+	categories []PercentCategory
+	rounding   RoundingPolicy
+	sanitation SanitationPolicy
+	units      map[string]string
+}
+
+// NewPercentStandard builds a percent-of-guideline standard, categories must
+// be ordered by From and the last one is open ended
+func NewPercentStandard(name string, guidelines map[string]float64, categories []PercentCategory) *PercentStandard {
+	return &PercentStandard{
+		name:       name,
+		guidelines: guidelines,
+		categories: categories,
+		rounding:   RoundingPolicy{Output: RoundHalfUp},
+		sanitation: DefaultSanitationPolicy,
+	}
+}
+
+// RoundingPolicy defaults to rounding indexes half-up
+func (std *PercentStandard) RoundingPolicy() RoundingPolicy {
+	return std.rounding
+}
+
+// SetRoundingPolicy replaces the rounding policy of the standard
+func (std *PercentStandard) SetRoundingPolicy(policy RoundingPolicy) {
+	std.rounding = policy
+}
+
+// SanitationPolicy defaults to DefaultSanitationPolicy
+func (std *PercentStandard) SanitationPolicy() SanitationPolicy {
+	return std.sanitation
+}
+
+// SetSanitationPolicy replaces the sanitation policy of the standard
+func (std *PercentStandard) SetSanitationPolicy(policy SanitationPolicy) {
+	std.sanitation = policy
+}
+
+// Unit returns the unit of a pollutant set by SetUnits
+func (std *PercentStandard) Unit(pollutant string) (string, error) {
+	unit, ok := std.units[pollutant]
+	if !ok {
+		return "", errors.New("Invalid pollutant metric")
+	}
+	return unit, nil
+}
+
+// SetUnits sets the units of the guidelines by pollutant
+func (std *PercentStandard) SetUnits(units map[string]string) {
+	std.units = units
+}
+
+func (std *PercentStandard) Name() string {
+	return std.name
+}
+
+func (std *PercentStandard) Pollutants() []string {
+	return sortedKeys(std.guidelines)
+}
+
+// Guideline returns the guideline concentration of a pollutant
+func (std *PercentStandard) Guideline(pollutant string) (float64, bool) {
+	v, ok := std.guidelines[pollutant]
+	return v, ok
+}
+
+// IAQI returns concentration/guideline×100 rounded by the rounding policy
+func (std *PercentStandard) IAQI(pollutant string, concentration float64) (int, error) {
+	iaqi, err := std.FloatIAQI(pollutant, concentration)
+	if err != nil || iaqi == 0 {
+		return int(iaqi), err
+	}
+	return std.rounding.Index(iaqi), nil
+}
+
+// FloatIAQI returns concentration/guideline×100 before rounding
+func (std *PercentStandard) FloatIAQI(pollutant string, concentration float64) (float64, error) {
+	unit, _ := std.Unit(pollutant)
+	concentration, iaqi, err := std.sanitation.apply(concentration, unit)
+	if err != nil || concentration == 0 {
+		return iaqi, err
+	}
+	guideline, ok := std.guidelines[pollutant]
+	if !ok || guideline <= 0 {
+		return -1, errors.New("Invalid pollutant metric")
+	}
+	return std.rounding.Input(pollutant, concentration) / guideline * 100, nil
+}
+
+func (std *PercentStandard) AQI(iaqis map[string]int) int {
+	return MaxAggregate(iaqis)
+}
+
+// BreakPoints returns the concentration range of every category, the open
+// ended category stops at MaxIndex
+func (std *PercentStandard) BreakPoints(pollutant string) ([]Band, error) {
+	guideline, ok := std.guidelines[pollutant]
+	if !ok {
+		return nil, errors.New("Invalid pollutant metric")
+	}
+	result := make([]Band, 0, len(std.categories))
+	for i, category := range std.categories {
+		to := category.To
+		if i == len(std.categories)-1 {
+			to = MaxIndex
+		}
+		result = append(result, Band{category.From, to, category.From * guideline / 100, to * guideline / 100})
+	}
+	return result, nil
+}
+
+func (std *PercentStandard) Categories() []Category {
+	result := make([]Category, 0, len(std.categories))
+	for i, category := range std.categories {
+		to := MaxIndex
+		if i < len(std.categories)-1 {
+			to = int(category.To)
+		}
+		result = append(result, Category{
+			Level: i + 1,
+			Name:  category.Name,
+			From:  int(category.From),
+			To:    to,
+		})
+	}
+	return result
+}
+
+// Category returns the band of an index value, it fails when the standard
+// has no categories
+func (std *PercentStandard) Category(iaqi int) (PercentCategory, error) {
+	if len(std.categories) == 0 {
+		return PercentCategory{}, errors.New("Standard " + std.name + " has no categories")
+	}
+	for _, category := range std.categories {
+		if float64(iaqi) <= category.To {
+			return category, nil
+		}
+	}
+	return std.categories[len(std.categories)-1], nil
+}
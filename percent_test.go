@@ -0,0 +1,42 @@
+package aqi
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPercentStandard(t *testing.T) {
+	std := NewPercentStandard("nz",
+		map[string]float64{"pm10_24h": 50, "no2_1h": 200},
+		[]PercentCategory{
+			PercentCategory{"Good", 0, 33},
+			PercentCategory{"Acceptable", 34, 66},
+			PercentCategory{"Alert", 67, 100},
+			PercentCategory{"Action", 101, math.MaxFloat64},
+		})
+
+	if v := std.Pollutants(); len(v) != 2 || v[0] != "no2_1h" {
+		t.Errorf("pollutants should be sorted, but %v", v)
+	}
+	if v, err := std.IAQI("foo", 42); err == nil || v != -1 {
+		t.Error("fake foo pollutant should raise exception")
+	}
+	if v, _ := std.IAQI("pm10_24h", 0); v != 0 {
+		t.Errorf("0 concentration should return 0, but %d", v)
+	}
+	if v, _ := std.IAQI("pm10_24h", 33.4); v != 67 {
+		t.Errorf("err %d, want 67", v)
+	}
+	if v, _ := std.IAQI("no2_1h", 250); v != 125 {
+		t.Errorf("err %d, want 125", v)
+	}
+	seeds := map[int]string{0: "Good", 33: "Good", 34: "Acceptable", 100: "Alert", 101: "Action", 911: "Action"}
+	for iaqi, name := range seeds {
+		if v, err := std.Category(iaqi); err != nil || v.Name != name {
+			t.Errorf("%d should be %s, but %s %v", iaqi, name, v.Name, err)
+		}
+	}
+	if _, err := NewPercentStandard("test-empty", map[string]float64{"pm25_24h": 25}, nil).Category(42); err == nil {
+		t.Error("standard without categories should fail with error")
+	}
+}
@@ -0,0 +1,409 @@
+package aqi
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"math"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultCompleteness is the minimum ratio of valid hours of the rolling
+// averages MinValidHours has no GB3095-2012 rule for
+const DefaultCompleteness = 0.75
+
+// Observation is a measurement of a pollutant by a station. Pollutant is the
+// species of a tag, "pm25" or "pm25_1h" for pm25_24h
+type Observation struct {
+	Station   string
+	Time      time.Time
+	Pollutant string
+	Value     float64
+	Unit      string
+}
+
+// StationResult is the result of a station at the hour beginning at Time,
+// Err is the error calculating its concentrations
+type StationResult struct {
+	Station string
+	Time    time.Time
+	Result
+	Err error
+}
+
+// PipelineConfig configures Stream
+type PipelineConfig struct {
+	Standard Standard
+	// Workers is the number of goroutines stations are sharded to, defaults
+	// to runtime.NumCPU()
+	Workers int
+	// Completeness overrides the minimum ratio of valid hours of every
+	// rolling average, MinValidHours of the averaging hours without it
+	Completeness float64
+	// Convert converts the value of an observation to the unit of the
+	// standard, values are used as is without it
+	Convert func(observation Observation) (float64, error)
+}
+
+// AveragingHours returns the averaging period of a pollutant tag in hours,
+// 24 for pm25_24h
+func AveragingHours(tag string) (int, error) {
+	i := strings.LastIndex(tag, "_")
+	if i < 0 || !strings.HasSuffix(tag, "h") {
+		return 0, errors.New("Invalid pollutant metric")
+	}
+	hours, err := strconv.Atoi(tag[i+1 : len(tag)-1])
+	if err != nil || hours <= 0 {
+		return 0, errors.New("Invalid pollutant metric")
+	}
+	return hours, nil
+}
+
+// species returns the pollutant of a tag without its averaging period
+func species(tag string) string {
+	if i := strings.LastIndex(tag, "_"); i >= 0 {
+		return tag[:i]
+	}
+	return tag
+}
+
+// RollingAverage returns the mean of the latest window hourly values, most
+// recent first, NaN values are missing. It fails when less than minValid
+// values are valid
+func RollingAverage(hourly []float64, window, minValid int) (float64, error) {
+	sum, n := 0.0, 0
+	for i := 0; i < window && i < len(hourly); i++ {
+		if !math.IsNaN(hourly[i]) {
+			sum += hourly[i]
+			n++
+		}
+	}
+	if n == 0 || n < minValid {
+		return math.NaN(), errors.New("Insufficient valid hours")
+	}
+	return sum / float64(n), nil
+}
+
+// MinValidHours returns the minimum valid hours of a rolling average of
+// hours, the ones of GB3095-2012 for 24h and 8h averages and
+// DefaultCompleteness of the other periods
+func MinValidHours(hours int) int {
+	switch hours {
+	case 24:
+		return Mep24HMinHours
+	case 8:
+		return Mep8HMinHours
+	}
+	return int(math.Ceil(DefaultCompleteness * float64(hours)))
+}
+
+// sanitizeHourly sanitizes the negative hourly values of a pollutant tag
+// with the policy of a standard, clamped values become 0 and missing ones
+// NaN. It fails on a rejected value, diagnostic is the one of the latest
+// negative value
+func sanitizeHourly(std Standard, tag string, hourly []float64) (diagnostic Diagnostic, err error) {
+	sanitizer, ok := std.(Sanitizer)
+	if !ok {
+		return diagnostic, nil
+	}
+	unit := ""
+	if uniter, ok := std.(Uniter); ok {
+		unit, _ = uniter.Unit(tag)
+	}
+	policy := sanitizer.SanitationPolicy()
+	for i := len(hourly) - 1; i >= 0; i-- {
+		if !(hourly[i] < 0) {
+			continue
+		}
+		var v float64
+		v, diagnostic, err = policy.Sanitize(hourly[i], unit)
+		switch err {
+		case nil:
+			hourly[i] = v
+		case ErrMissingConcentration:
+			hourly[i] = math.NaN()
+		default:
+			return diagnostic, err
+		}
+	}
+	return diagnostic, nil
+}
+
+// averagingWindows returns the averaging hours of the pollutants of a
+// standard
+func averagingWindows(std Standard) map[string]int {
+	windows := make(map[string]int)
+	for _, tag := range std.Pollutants() {
+		if hours, err := AveragingHours(tag); err == nil {
+			windows[tag] = hours
+		}
+	}
+	return windows
+}
+
+// calculateHourly calculates the rolling averages of every pollutant of
+// windows, hourly returns the latest hourly values of a species most recent
+// first, or nil without any. Negative hourly values follow the sanitation
+// policy of the standard, completeness overrides MinValidHours when > 0
+func calculateHourly(std Standard, windows map[string]int, completeness float64, hourly func(species string, hours int) []float64) (Result, error) {
+	concentrations := make(map[string]float64)
+	diagnostics := make(map[string]Diagnostic)
+	for _, tag := range sortedKeys(windows) {
+		window := windows[tag]
+		values := hourly(species(tag), window)
+		if values == nil {
+			continue
+		}
+		diagnostic, err := sanitizeHourly(std, tag, values)
+		if err != nil {
+			return Result{Standard: std.Name(), Concentrations: concentrations}, errors.New(tag + ": " + err.Error())
+		}
+		if diagnostic.Issue != IssueNone {
+			diagnostics[tag] = diagnostic
+		}
+		minValid := MinValidHours(window)
+		if completeness > 0 {
+			minValid = int(math.Ceil(completeness * float64(window)))
+		}
+		if v, err := RollingAverage(values, window, minValid); err == nil {
+			concentrations[tag] = v
+		}
+	}
+	if len(concentrations) == 0 {
+		return Result{Standard: std.Name(), Concentrations: concentrations}, errors.New("No concentration")
+	}
+	result, err := Calculate(std, concentrations)
+	for tag, diagnostic := range diagnostics {
+		if result.Diagnostics == nil {
+			result.Diagnostics = make(map[string]Diagnostic)
+		}
+		result.Diagnostics[tag] = diagnostic
+	}
+	return result, err
+}
+
+// Stream calculates the results of observations read from in, every station
+// emits the result of an hour once an observation of a later hour arrives,
+// and the result of its latest hour when in is closed. Observations of a
+// station must be sent in order of hours, observations of a past hour are
+// still averaged into it while it is in the rolling window.
+//
+// Stations are sharded to workers, results of a station are emitted in
+// order. Results are sent unbuffered, a slow reader blocks the pipeline and
+// so the writer of in. The returned channel is closed when in is closed and
+// drained, or ctx is done
+func Stream(ctx context.Context, in <-chan Observation, config PipelineConfig) (<-chan StationResult, error) {
+	if config.Standard == nil {
+		return nil, errors.New("Missing standard")
+	}
+	if config.Workers <= 0 {
+		config.Workers = runtime.NumCPU()
+	}
+	if config.Completeness < 0 || config.Completeness > 1 {
+		return nil, errors.New("Invalid completeness")
+	}
+	windows := averagingWindows(config.Standard)
+	retention := 1
+	for _, hours := range windows {
+		if hours > retention {
+			retention = hours
+		}
+	}
+
+	out := make(chan StationResult)
+	shards := make([]chan Observation, config.Workers)
+	done := make(chan struct{})
+	for i := range shards {
+		shards[i] = make(chan Observation)
+		w := &streamWorker{
+			config:    config,
+			windows:   windows,
+			retention: retention,
+			stations:  make(map[string]*stationHours),
+			out:       out,
+		}
+		go func(in <-chan Observation) {
+			w.run(ctx, in)
+			done <- struct{}{}
+		}(shards[i])
+	}
+
+	// dispatch observations to the worker of their station
+	go func() {
+		defer func() {
+			for _, shard := range shards {
+				close(shard)
+			}
+		}()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case observation, ok := <-in:
+				if !ok {
+					return
+				}
+				h := fnv.New32a()
+				h.Write([]byte(observation.Station))
+				select {
+				case shards[h.Sum32()%uint32(len(shards))] <- observation:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	go func() {
+		for range shards {
+			<-done
+		}
+		close(out)
+	}()
+	return out, nil
+}
+
+// hourBin sums the observations of a pollutant in an hour
+type hourBin struct {
+	sum   float64
+	count int
+}
+
+// stationHours keeps the hourly bins of a station, hours are counted from
+// the Unix epoch
+type stationHours struct {
+	current int64
+	bins    map[string]map[int64]*hourBin
+	// errs are conversion errors of the current hour
+	errs []string
+}
+
+type streamWorker struct {
+	config    PipelineConfig
+	windows   map[string]int
+	retention int
+	stations  map[string]*stationHours
+	out       chan<- StationResult
+}
+
+func (w *streamWorker) run(ctx context.Context, in <-chan Observation) {
+	for observation := range in {
+		if !w.observe(ctx, observation) {
+			// drain the dispatcher, it stops on ctx too
+			for range in {
+			}
+			return
+		}
+	}
+	names := make([]string, 0, len(w.stations))
+	for name := range w.stations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !w.emit(ctx, name, w.stations[name]) {
+			return
+		}
+	}
+}
+
+// observe adds an observation, it returns false when ctx is done
+func (w *streamWorker) observe(ctx context.Context, observation Observation) bool {
+	hour := observation.Time.Truncate(time.Hour).Unix() / 3600
+	station, ok := w.stations[observation.Station]
+	if !ok {
+		station = &stationHours{current: hour, bins: make(map[string]map[int64]*hourBin)}
+		w.stations[observation.Station] = station
+	}
+	if hour > station.current {
+		if !w.emit(ctx, observation.Station, station) {
+			return false
+		}
+		station.current = hour
+		station.errs = nil
+		for _, bins := range station.bins {
+			for h := range bins {
+				if h <= hour-int64(w.retention) {
+					delete(bins, h)
+				}
+			}
+		}
+	}
+	if hour <= station.current-int64(w.retention) {
+		return true
+	}
+
+	value := observation.Value
+	if w.config.Convert != nil {
+		v, err := w.config.Convert(observation)
+		if err != nil {
+			station.errs = append(station.errs, observation.Pollutant+": "+err.Error())
+			return true
+		}
+		value = v
+	}
+	// negatives are sanitized by the policy of the standard once averaged
+	// into the hour
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return true
+	}
+	name := observation.Pollutant
+	if _, err := AveragingHours(name); err == nil {
+		name = species(name)
+	}
+	bins, ok := station.bins[name]
+	if !ok {
+		bins = make(map[int64]*hourBin)
+		station.bins[name] = bins
+	}
+	bin, ok := bins[hour]
+	if !ok {
+		bin = &hourBin{}
+		bins[hour] = bin
+	}
+	bin.sum += value
+	bin.count++
+	return true
+}
+
+// emit sends the result of the current hour of a station, it returns false
+// when ctx is done
+func (w *streamWorker) emit(ctx context.Context, name string, station *stationHours) bool {
+	result := StationResult{
+		Station: name,
+		Time:    time.Unix(station.current*3600, 0).UTC(),
+	}
+	result.Result, result.Err = calculateHourly(w.config.Standard, w.windows, w.config.Completeness,
+		func(name string, hours int) []float64 {
+			bins, ok := station.bins[name]
+			if !ok {
+				return nil
+			}
+			hourly := make([]float64, hours)
+			for i := range hourly {
+				hourly[i] = math.NaN()
+				if bin, ok := bins[station.current-int64(i)]; ok {
+					hourly[i] = bin.sum / float64(bin.count)
+				}
+			}
+			return hourly
+		})
+	if len(station.errs) > 0 {
+		msg := strings.Join(station.errs, "; ")
+		if result.Err != nil {
+			msg = result.Err.Error() + "; " + msg
+		}
+		result.Err = errors.New(msg)
+	}
+
+	select {
+	case w.out <- result:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,211 @@
+package aqi
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestAveragingHours(t *testing.T) {
+	type Seed struct {
+		Tag   string
+		Hours int
+	}
+	seeds := []Seed{
+		{"pm25_24h", 24},
+		{"o3_8h", 8},
+		{"so2_1h", 1},
+		{"pm10_12h", 12},
+	}
+	for _, seed := range seeds {
+		if hours, err := AveragingHours(seed.Tag); err != nil || hours != seed.Hours {
+			t.Errorf("%s should be %d hours, but %d %v", seed.Tag, seed.Hours, hours, err)
+		}
+	}
+	for _, tag := range []string{"pm25", "pm25_h", "pm25_0h", "pm25_24"} {
+		if _, err := AveragingHours(tag); err == nil {
+			t.Errorf("%s should fail with error", tag)
+		}
+	}
+}
+
+func TestMinValidHours(t *testing.T) {
+	seeds := map[int]int{24: Mep24HMinHours, 8: Mep8HMinHours, 12: 9, 1: 1}
+	for hours, expect := range seeds {
+		if v := MinValidHours(hours); v != expect {
+			t.Errorf("%d hours should require %d valid hours, but %d", hours, expect, v)
+		}
+	}
+	if _, err := Stream(context.Background(), nil, PipelineConfig{Standard: MepStandard, Completeness: 2}); err == nil {
+		t.Error("completeness over 1 should fail with error")
+	}
+}
+
+func TestRollingAverage(t *testing.T) {
+	nan := math.NaN()
+	hourly := []float64{10, nan, 20, 30, 100}
+	if v, err := RollingAverage(hourly, 4, 3); err != nil || v != 20 {
+		t.Errorf("rolling average should be 20, but %v %v", v, err)
+	}
+	if _, err := RollingAverage(hourly, 4, 4); err == nil {
+		t.Error("3 valid of 4 hours should fail with error")
+	}
+	if _, err := RollingAverage([]float64{nan}, 1, 0); err == nil {
+		t.Error("no valid hour should fail with error")
+	}
+}
+
+// feed sends observations and closes in
+func feed(in chan<- Observation, observations []Observation) {
+	for _, observation := range observations {
+		in <- observation
+	}
+	close(in)
+}
+
+func TestStream(t *testing.T) {
+	start := time.Date(2013, 1, 15, 0, 0, 0, 0, time.UTC)
+	var observations []Observation
+	for hour := 0; hour < 24; hour++ {
+		at := start.Add(time.Duration(hour) * time.Hour)
+		for _, station := range []string{"1001", "1002", "1003"} {
+			// two observations averaged into the hour
+			observations = append(observations,
+				Observation{Station: station, Time: at, Pollutant: "pm25", Value: 80},
+				Observation{Station: station, Time: at.Add(30 * time.Minute), Pollutant: "pm25_1h", Value: 84},
+				Observation{Station: station, Time: at, Pollutant: "pm10", Value: 113},
+			)
+		}
+	}
+
+	in := make(chan Observation)
+	go feed(in, observations)
+	out, err := Stream(context.Background(), in, PipelineConfig{Standard: MepStandard, Workers: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results := make(map[string][]StationResult)
+	for result := range out {
+		results[result.Station] = append(results[result.Station], result)
+	}
+	for _, station := range []string{"1001", "1002", "1003"} {
+		if len(results[station]) != 24 {
+			t.Fatalf("%s should emit 24 hours, but %d", station, len(results[station]))
+		}
+		for hour, result := range results[station] {
+			if !result.Time.Equal(start.Add(time.Duration(hour) * time.Hour)) {
+				t.Errorf("%s results should be ordered by hour, but %v at %d", station, result.Time, hour)
+			}
+			// 20 valid hours of 24 are required
+			if hour < 19 {
+				if result.Err == nil {
+					t.Errorf("%s hour %d should fail with error", station, hour)
+				}
+				continue
+			}
+			if result.Err != nil || result.Concentrations["pm25_24h"] != 82 || result.AQI != 109 {
+				t.Errorf("%s hour %d should be AQI 109, but %v %v", station, hour, result.Result, result.Err)
+			}
+		}
+	}
+}
+
+func TestStreamSanitation(t *testing.T) {
+	at := time.Date(2013, 1, 15, 0, 0, 0, 0, time.UTC)
+	in := make(chan Observation)
+	go feed(in, []Observation{
+		{Station: "1001", Time: at, Pollutant: "no2", Value: -3},
+		{Station: "1001", Time: at, Pollutant: "so2", Value: 30},
+		{Station: "1002", Time: at, Pollutant: "no2", Value: -500},
+	})
+	out, err := Stream(context.Background(), in, PipelineConfig{Standard: MepStandard, Workers: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	results := make(map[string]StationResult)
+	for result := range out {
+		results[result.Station] = result
+	}
+	if result := results["1001"]; result.Err != nil || result.Concentrations["no2_1h"] != 0 ||
+		result.Diagnostics["no2_1h"] != (Diagnostic{IssueNegative, ActionClamped}) {
+		t.Errorf("no2 -3 should be clamped to 0, but %v %v", result.Result, result.Err)
+	}
+	if result := results["1002"]; result.Err == nil {
+		t.Error("no2 -500 should be rejected")
+	}
+}
+
+func TestStreamConvert(t *testing.T) {
+	at := time.Date(2013, 1, 15, 0, 0, 0, 0, time.UTC)
+	in := make(chan Observation)
+	go feed(in, []Observation{
+		{Station: "1001", Time: at, Pollutant: "no2", Value: 0.1, Unit: "mg/m3"},
+		{Station: "1001", Time: at, Pollutant: "so2", Value: 1, Unit: "ppm"},
+	})
+	out, err := Stream(context.Background(), in, PipelineConfig{
+		Standard: MepStandard,
+		Convert: func(observation Observation) (float64, error) {
+			if observation.Unit != "mg/m3" {
+				return 0, errors.New("Unsupported unit " + observation.Unit)
+			}
+			return observation.Value * 1000, nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := <-out
+	if result.Concentrations["no2_1h"] != 100 || result.IAQIs["no2_1h"] != 50 {
+		t.Errorf("no2_1h should be 100 of IAQI 50, but %v", result.Result)
+	}
+	if result.Err == nil || result.Err.Error() != "so2: Unsupported unit ppm" {
+		t.Errorf("unsupported unit should be reported, but %v", result.Err)
+	}
+	if _, ok := <-out; ok {
+		t.Error("out should be closed")
+	}
+}
+
+func TestStreamBackpressureAndCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan Observation)
+	out, err := Stream(ctx, in, PipelineConfig{Standard: MepStandard, Workers: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := time.Date(2013, 1, 15, 0, 0, 0, 0, time.UTC)
+	blocked := false
+	// nobody reads out, the second hour blocks the worker and then the writer
+	for hour := 0; hour < 4 && !blocked; hour++ {
+		select {
+		case in <- Observation{Station: "1001", Time: at.Add(time.Duration(hour) * time.Hour), Pollutant: "so2", Value: 10}:
+		case <-time.After(100 * time.Millisecond):
+			blocked = true
+		}
+	}
+	if !blocked {
+		t.Error("an unread pipeline should block the writer")
+	}
+
+	cancel()
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case _, ok := <-out:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatal("out should be closed when ctx is done")
+		}
+	}
+}
+
+func TestStreamMissingStandard(t *testing.T) {
+	if _, err := Stream(context.Background(), nil, PipelineConfig{}); err == nil {
+		t.Error("missing standard should fail with error")
+	}
+}
@@ -0,0 +1,288 @@
+package aqi
+
+import (
+	"errors"
+	"math"
+)
+
+// Singapore NEA Pollutant Standards Index(PSI)
+type PsiPollutant struct {
+	SO2Pollutant24H  float64 `json:"so2_24h" truncate:"0"`  // µg/m³
+	NO2Pollutant1H   float64 `json:"no2_1h" truncate:"0"`   // µg/m³
+	COPollutant8H    float64 `json:"co_8h" truncate:"1"`    // mg/m³
+	O3Pollutant8H    float64 `json:"o3_8h" truncate:"0"`    // µg/m³
+	PM10Pollutant24H float64 `json:"pm10_24h" truncate:"0"` // µg/m³
+	PM25Pollutant24H float64 `json:"pm25_24h" truncate:"0"` // µg/m³
+	PM25Pollutant1H  float64 `json:"pm25_1h"`               // µg/m³, advisory band only
+}
+
+type PSIBreakPoint struct {
+	From, To float64
+}
+
+// PSIPM25Band is the 1-hr PM2.5 concentration band NEA publishes for
+// health advisories
+type PSIPM25Band struct {
+	Band       int
+	Descriptor string
+	From, To   float64
+}
+
+var (
+	psiIAQIs          []PSIBreakPoint
+	psiCategories     []Category
+	psiConcentrations map[string][]PSIBreakPoint
+	psiComputableMaxs map[string]float64
+	psiTruncateRules  map[string]int
+	psiPM25Bands      []PSIPM25Band
+)
+
+type psiStandard struct {
+	beyondIndex BeyondIndexPolicy
+}
+
+// defaultPsi is the PsiStandard of the package level functions, it flags
+// concentrations above the top band
+var defaultPsi = psiStandard{beyondIndex: BeyondIndexFlag}
+
+// PsiStandard is the Singapore 24-hr PSI standard
+var PsiStandard Standard = defaultPsi
+
+func (psiStandard) Name() string {
+	return "psi"
+}
+
+func (psiStandard) Pollutants() []string {
+	return sortedKeys(psiComputableMaxs)
+}
+
+func (std psiStandard) IAQI(pollutant string, concentration float64) (int, error) {
+	iaqi, interpolated, err := std.iaqi(pollutant, concentration)
+	if !interpolated {
+		return int(iaqi), err
+	}
+	return PsiRoundingPolicy.Index(iaqi), nil
+}
+
+func (psiStandard) AQI(iaqis map[string]int) int {
+	return MaxAggregate(iaqis)
+}
+
+// PsiRoundingPolicy truncates concentrations by the `truncate` struct tags
+// of PsiPollutant and rounds indexes half-up
+var PsiRoundingPolicy RoundingPolicy
+
+func (std psiStandard) FloatIAQI(pollutant string, concentration float64) (float64, error) {
+	iaqi, _, err := std.iaqi(pollutant, concentration)
+	return iaqi, err
+}
+
+func (psiStandard) RoundingPolicy() RoundingPolicy {
+	return PsiRoundingPolicy
+}
+
+func (std psiStandard) BeyondIndexPolicy() BeyondIndexPolicy {
+	return std.beyondIndex
+}
+
+// PsiSanitationPolicy sanitizes concentrations before indexing
+var PsiSanitationPolicy = DefaultSanitationPolicy
+
+func (psiStandard) SanitationPolicy() SanitationPolicy {
+	return PsiSanitationPolicy
+}
+
+func (psiStandard) Unit(pollutant string) (string, error) {
+	if !psiPollutantCalculable(pollutant) {
+		return "", errors.New("Invalid pollutant metric")
+	}
+	return unitOf(ugm3Units, pollutant)
+}
+
+func (psiStandard) BreakPoints(pollutant string) ([]Band, error) {
+	return breakPointBands(psiConcentrations, psiIAQIs, pollutant)
+}
+
+func (psiStandard) Categories() []Category {
+	return psiCategories
+}
+
+func init() {
+	psiCategories = []Category{
+		Category{Level: 1, Name: "Good", From: 0, To: 50,
+			ColorName: "GREEN", Color: Color{R: 0, G: 228, B: 0, C: 100, M: 0, Y: 100, K: 11},
+			Advice: "Normal activities."},
+		Category{Level: 2, Name: "Moderate", From: 51, To: 100,
+			ColorName: "BLUE", Color: Color{R: 0, G: 102, B: 255, C: 100, M: 60, Y: 0, K: 0},
+			Advice: "Normal activities."},
+		Category{Level: 3, Name: "Unhealthy", From: 101, To: 200,
+			ColorName: "YELLOW", Color: Color{R: 255, G: 255, B: 0, C: 0, M: 0, Y: 100, K: 0},
+			Advice: "Reduce prolonged or strenuous outdoor physical exertion."},
+		Category{Level: 4, Name: "Very Unhealthy", From: 201, To: 300,
+			ColorName: "ORANGE", Color: Color{R: 255, G: 126, B: 0, C: 0, M: 51, Y: 100, K: 0},
+			Advice: "Avoid prolonged or strenuous outdoor physical exertion."},
+		Category{Level: 5, Name: "Hazardous", From: 301, To: 500,
+			ColorName: "RED", Color: Color{R: 255, G: 0, B: 0, C: 0, M: 100, Y: 100, K: 0},
+			Advice: "Minimise outdoor activity."},
+	}
+
+	psiIAQIs = []PSIBreakPoint{
+		//0, 50, 100, 200, 300, 400, 500
+		PSIBreakPoint{0, 50},
+		PSIBreakPoint{51, 100},
+		PSIBreakPoint{101, 200},
+		PSIBreakPoint{201, 300},
+		PSIBreakPoint{301, 400},
+		PSIBreakPoint{401, 500},
+	}
+
+	psiConcentrations = make(map[string][]PSIBreakPoint)
+	psiComputableMaxs = make(map[string]float64)
+
+	psiConcentrations["pm25_24h"] = []PSIBreakPoint{
+		//0, 12, 55, 150, 250, 350, 500
+		PSIBreakPoint{0, 12},
+		PSIBreakPoint{13, 55},
+		PSIBreakPoint{56, 150},
+		PSIBreakPoint{151, 250},
+		PSIBreakPoint{251, 350},
+		PSIBreakPoint{351, 500},
+	}
+
+	psiConcentrations["pm10_24h"] = []PSIBreakPoint{
+		//0, 50, 150, 350, 420, 500, 600
+		PSIBreakPoint{0, 50},
+		PSIBreakPoint{51, 150},
+		PSIBreakPoint{151, 350},
+		PSIBreakPoint{351, 420},
+		PSIBreakPoint{421, 500},
+		PSIBreakPoint{501, 600},
+	}
+
+	psiConcentrations["so2_24h"] = []PSIBreakPoint{
+		//0, 80, 365, 800, 1600, 2100, 2620
+		PSIBreakPoint{0, 80},
+		PSIBreakPoint{81, 365},
+		PSIBreakPoint{366, 800},
+		PSIBreakPoint{801, 1600},
+		PSIBreakPoint{1601, 2100},
+		PSIBreakPoint{2101, 2620},
+	}
+
+	psiConcentrations["co_8h"] = []PSIBreakPoint{
+		//0, 5.0, 10.0, 17.0, 34.0, 46.0, 57.5
+		PSIBreakPoint{0.0, 5.0},
+		PSIBreakPoint{5.1, 10.0},
+		PSIBreakPoint{10.1, 17.0},
+		PSIBreakPoint{17.1, 34.0},
+		PSIBreakPoint{34.1, 46.0},
+		PSIBreakPoint{46.1, 57.5},
+	}
+
+	psiConcentrations["o3_8h"] = []PSIBreakPoint{
+		//0, 118, 157, 235, 785, 980, 1180
+		PSIBreakPoint{0, 118},
+		PSIBreakPoint{119, 157},
+		PSIBreakPoint{158, 235},
+		PSIBreakPoint{236, 785},
+		PSIBreakPoint{786, 980},
+		PSIBreakPoint{981, 1180},
+	}
+
+	// no2 only reported when PSI above 200
+	psiConcentrations["no2_1h"] = []PSIBreakPoint{
+		//-, -, -, 1130, 2260, 3000, 3750
+		PSIBreakPoint(unreportedBreakPoint),
+		PSIBreakPoint(unreportedBreakPoint),
+		PSIBreakPoint(unreportedBreakPoint),
+		PSIBreakPoint{1131, 2260},
+		PSIBreakPoint{2261, 3000},
+		PSIBreakPoint{3001, 3750},
+	}
+
+	for k, v := range psiConcentrations {
+		psiComputableMaxs[k] = v[len(v)-1].To
+	}
+
+	psiTruncateRules = truncateRules(&PsiPollutant{}, psiPollutantCalculable)
+	PsiRoundingPolicy = RoundingPolicy{InputDigits: psiTruncateRules, Output: RoundHalfUp}
+
+	psiPM25Bands = []PSIPM25Band{
+		PSIPM25Band{1, "Normal", 0, 55},
+		PSIPM25Band{2, "Elevated", 56, 150},
+		PSIPM25Band{3, "High", 151, 250},
+		PSIPM25Band{4, "Very High", 251, math.MaxFloat64},
+	}
+
+	RegisterStandard(PsiStandard)
+}
+
+func psiPollutantCalculable(pollutant string) bool {
+	return psiComputableMaxs[pollutant] > 0
+}
+
+// iaqi returns the unrounded IAQI, interpolated is false for the sentinel
+// values of IAQI
+func (std psiStandard) iaqi(pollutant string, concentration float64) (iaqi float64, interpolated bool, err error) {
+	unit, _ := std.Unit(pollutant)
+	if concentration, iaqi, err = PsiSanitationPolicy.apply(concentration, unit); err != nil || concentration == 0 {
+		return iaqi, false, err
+	}
+	if !psiPollutantCalculable(pollutant) {
+		return -1, false, errors.New("Invalid pollutant metric")
+	}
+	concentration = PsiRoundingPolicy.Input(pollutant, concentration)
+	if concentration > psiComputableMaxs[pollutant] {
+		return std.beyondIndex.apply(concentration, topBand(std, pollutant))
+	}
+	bands, _ := std.BreakPoints(pollutant)
+	band, v, ok := lookupBand(bands, concentration)
+	if !ok {
+		// below the lowest reported band
+		return 0, false, nil
+	}
+	return band.interpolate(v)
+}
+
+func GetPsiIAQI(pollutant string, concentration float64) (int, error) {
+	return defaultPsi.IAQI(pollutant, concentration)
+}
+
+// GetPsiIAQIFloat returns the IAQI of GetPsiIAQI before rounding
+func GetPsiIAQIFloat(pollutant string, concentration float64) (float64, error) {
+	return defaultPsi.FloatIAQI(pollutant, concentration)
+}
+
+func GetPsiPM25IAQI(concentration float64) (int, error) {
+	return GetPsiIAQI("pm25_24h", concentration)
+}
+
+// GetPsiPM25Band returns the 1-hr PM2.5 concentration band
+func GetPsiPM25Band(concentration float64) PSIPM25Band {
+	concentration = TruncateFloat(concentration, 0)
+	for _, band := range psiPM25Bands {
+		if concentration <= band.To {
+			return band
+		}
+	}
+	return psiPM25Bands[len(psiPM25Bands)-1]
+}
+
+// GetAllIAQI is GetAllIAQI of PsiStandard, which keeps the default
+// BeyondIndexFlag whatever WithBeyondIndexPolicy returns
+func (psi *PsiPollutant) GetAllIAQI() map[string]int {
+	return GetAllIAQI(PsiStandard, psi)
+}
+
+func (psi *PsiPollutant) GetAQI() int {
+	return PsiStandard.AQI(psi.GetAllIAQI())
+}
+
+func (psi *PsiPollutant) ResponsiblePollutants() []string {
+	return maxPollutants(psi.GetAllIAQI())
+}
+
+// PM25Band returns the 1-hr PM2.5 concentration band
+func (psi *PsiPollutant) PM25Band() PSIPM25Band {
+	return GetPsiPM25Band(psi.PM25Pollutant1H)
+}
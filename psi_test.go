@@ -0,0 +1,91 @@
+package aqi
+
+import (
+	"testing"
+)
+
+var (
+	validPSIPollutants = []string{"so2_24h", "no2_1h", "co_8h", "o3_8h", "pm10_24h", "pm25_24h"}
+)
+
+func TestPsiPollutantCalculable(t *testing.T) {
+	for _, v := range validPSIPollutants {
+		if !psiPollutantCalculable(v) {
+			t.Errorf("%s should be calculable", v)
+		}
+	}
+	for _, v := range []string{"foo", "pm25_1h"} {
+		if psiPollutantCalculable(v) {
+			t.Errorf("%s should not be calculable", v)
+		}
+	}
+}
+
+func TestGetPsiIAQI(t *testing.T) {
+	iaqi, err := GetPsiIAQI("foo", 42)
+	if err == nil {
+		t.Error("fake foo pollutant should raise exception")
+	}
+	if iaqi != -1 {
+		t.Error("fake foo pollutant with gt 0 value should return -1")
+	}
+
+	for _, pollutant := range validPSIPollutants {
+		if iaqi, _ := GetPsiIAQI(pollutant, psiComputableMaxs[pollutant]+5); iaqi != BeyondIndex {
+			t.Errorf("%s over max should return BeyondIndex, but return %d", pollutant, iaqi)
+		}
+	}
+
+	type Seed struct {
+		Pollutant     string
+		Concentration float64
+		Expection     int
+	}
+
+	seeds := []Seed{
+		Seed{"pm25_24h", 12, 50}, Seed{"pm25_24h", 55, 100},
+		Seed{"pm25_24h", 34, 76}, Seed{"pm10_24h", 150, 100},
+		Seed{"co_8h", 10.1, 101}, Seed{"o3_8h", 235, 200},
+		Seed{"so2_24h", 2620, 500},
+		// no2 is not reported below PSI 201
+		Seed{"no2_1h", 200, 0}, Seed{"no2_1h", 1131, 201},
+	}
+
+	for _, seed := range seeds {
+		v, err := GetPsiIAQI(seed.Pollutant, seed.Concentration)
+		if err != nil {
+			t.Errorf("%s with %f should not raise exception %s", seed.Pollutant, seed.Concentration, err)
+		}
+		if v != seed.Expection {
+			t.Errorf("%s with %f should return %d, but %d", seed.Pollutant, seed.Concentration, seed.Expection, v)
+		}
+	}
+}
+
+func TestGetPsiPM25Band(t *testing.T) {
+	seeds := map[float64]int{0: 1, 55: 1, 56: 2, 150: 2, 151: 3, 250: 3, 251: 4, 900: 4}
+	for concentration, band := range seeds {
+		if v := GetPsiPM25Band(concentration); v.Band != band {
+			t.Errorf("pm25_1h with %f should be band %d, but %d", concentration, band, v.Band)
+		}
+	}
+}
+
+func TestPsiGetAQI(t *testing.T) {
+	psi := &PsiPollutant{
+		PM25Pollutant24H: 34,
+		PM10Pollutant24H: 40,
+		O3Pollutant8H:    60,
+		PM25Pollutant1H:  70,
+	}
+	if v := psi.GetAQI(); v != 76 {
+		t.Errorf("should pass with %d", v)
+	}
+	result := psi.ResponsiblePollutants()
+	if len(result) != 1 || result[0] != "pm25_24h" {
+		t.Errorf("should be pm25_24h, but %v", result)
+	}
+	if band := psi.PM25Band(); band.Band != 2 {
+		t.Errorf("should be band 2, but %d", band.Band)
+	}
+}
@@ -0,0 +1,124 @@
+package aqi
+
+import (
+	"errors"
+	"sort"
+)
+
+// Species is a chemical species or particulate matter size fraction.
+// MolecularWeight in g/mol is 0 for particulate matter
+type Species struct {
+	ID              string  `json:"id"`
+	Formula         string  `json:"formula"`
+	Name            string  `json:"name"`
+	NameZh          string  `json:"name_zh"`
+	MolecularWeight float64 `json:"molecular_weight,omitempty"`
+}
+
+var speciesRegistry = map[string]Species{
+	"so2":  {"so2", "SO₂", "Sulfur Dioxide", "二氧化硫", 64.066},
+	"no2":  {"no2", "NO₂", "Nitrogen Dioxide", "二氧化氮", 46.0055},
+	"co":   {"co", "CO", "Carbon Monoxide", "一氧化碳", 28.010},
+	"o3":   {"o3", "O₃", "Ozone", "臭氧", 47.997},
+	"pm10": {"pm10", "PM10", "Particulate Matter (PM10)", "可吸入颗粒物(PM10)", 0},
+	"pm25": {"pm25", "PM2.5", "Fine Particulate Matter (PM2.5)", "细颗粒物(PM2.5)", 0},
+}
+
+// LookupSpecies returns a species by id, e.g. "pm25"
+func LookupSpecies(id string) (Species, error) {
+	s, ok := speciesRegistry[id]
+	if !ok {
+		return Species{}, errors.New("Unknown species " + id)
+	}
+	return s, nil
+}
+
+// PollutantInfo describes a pollutant tag, a species over an averaging
+// period. Name and NameZh are the names of Localizer.PollutantName in en and
+// zh-Hans. Units are keyed by the names of the standards supporting the tag,
+// "" for standards without units
+type PollutantInfo struct {
+	Tag            string            `json:"tag"`
+	Species        Species           `json:"species"`
+	AveragingHours int               `json:"averaging_hours"`
+	Name           string            `json:"name"`
+	NameZh         string            `json:"name_zh"`
+	Units          map[string]string `json:"units"`
+}
+
+// Standards returns the names of the standards supporting the tag
+func (info PollutantInfo) Standards() []string {
+	result := make([]string, 0, len(info.Units))
+	for name := range info.Units {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// RegisteredPollutants returns the registry of every pollutant tag of the
+// registered standards sorted by tag. It's built from the standards at
+// runtime so standards registered later are included
+func RegisteredPollutants() []PollutantInfo {
+	infos := make(map[string]*PollutantInfo)
+	for _, name := range StandardNames() {
+		std, err := GetStandard(name)
+		if err != nil {
+			continue
+		}
+		uniter, _ := std.(Uniter)
+		for _, tag := range std.Pollutants() {
+			info, ok := infos[tag]
+			if !ok {
+				info = newPollutantInfo(tag)
+				infos[tag] = info
+			}
+			unit := ""
+			if uniter != nil {
+				unit, _ = uniter.Unit(tag)
+			}
+			info.Units[name] = unit
+		}
+	}
+	result := make([]PollutantInfo, 0, len(infos))
+	for _, info := range infos {
+		result = append(result, *info)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Tag < result[j].Tag })
+	return result
+}
+
+func newPollutantInfo(tag string) *PollutantInfo {
+	info := &PollutantInfo{Tag: tag, Units: make(map[string]string)}
+	info.Species, _ = LookupSpecies(species(tag))
+	if info.Species.ID == "" {
+		info.Species = Species{ID: species(tag), Formula: species(tag), Name: species(tag), NameZh: species(tag)}
+	}
+	info.AveragingHours, _ = AveragingHours(tag)
+	en, _ := NewLocalizer(DefaultLocale)
+	zh, _ := NewLocalizer("zh-Hans")
+	info.Name = en.PollutantName(tag)
+	info.NameZh = zh.PollutantName(tag)
+	return info
+}
+
+// LookupPollutant returns the registry entry of a pollutant tag
+func LookupPollutant(tag string) (PollutantInfo, error) {
+	for _, info := range RegisteredPollutants() {
+		if info.Tag == tag {
+			return info, nil
+		}
+	}
+	return PollutantInfo{}, errors.New("Unknown pollutant tag " + tag)
+}
+
+// PollutantTags returns the sorted pollutant tags of a registered standard
+func PollutantTags(standard string) ([]string, error) {
+	std, err := GetStandard(standard)
+	if err != nil {
+		return nil, err
+	}
+	result := std.Pollutants()
+	sort.Strings(result)
+	return result, nil
+}
@@ -0,0 +1,97 @@
+package aqi
+
+import (
+	"strings"
+	"testing"
+)
+
+// registeredTags returns the tags of the registry supported by a standard
+func registeredTags(standard string) []string {
+	var result []string
+	for _, info := range RegisteredPollutants() {
+		if _, ok := info.Units[standard]; ok {
+			result = append(result, info.Tag)
+		}
+	}
+	return result
+}
+
+func TestRegisteredPollutants(t *testing.T) {
+	expected := map[string]string{
+		"co_1h":    "cai mep",
+		"co_24h":   "mep",
+		"co_8h":    "epa mex nsw psi",
+		"no2_1h":   "cai epa mep mex nsw psi",
+		"no2_24h":  "mep",
+		"o3_1h":    "cai epa mep mex",
+		"o3_8h":    "epa mep mex nsw psi",
+		"pm10_12h": "mex",
+		"pm10_24h": "cai epa mep nsw psi",
+		"pm25_12h": "mex",
+		"pm25_24h": "cai epa mep nsw psi",
+		"so2_1h":   "cai epa mep nsw",
+		"so2_24h":  "mep mex psi",
+	}
+	infos := RegisteredPollutants()
+	if len(infos) != len(expected) {
+		t.Errorf("registry should have %d tags, but %d", len(expected), len(infos))
+	}
+	for _, info := range infos {
+		if v := strings.Join(info.Standards(), " "); v != expected[info.Tag] {
+			t.Errorf("%s should be supported by %s, but %s", info.Tag, expected[info.Tag], v)
+		}
+	}
+
+	if v := strings.Join(registeredTags("epa"), " "); v != "co_8h no2_1h o3_1h o3_8h pm10_24h pm25_24h so2_1h" {
+		t.Errorf("epa tags should be sorted, but %s", v)
+	}
+}
+
+func TestLookupPollutant(t *testing.T) {
+	info, err := LookupPollutant("o3_8h")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Species.ID != "o3" || info.Species.MolecularWeight != 47.997 || info.AveragingHours != 8 {
+		t.Errorf("o3_8h should be 8h ozone, but %+v", info)
+	}
+	l, _ := NewLocalizer("zh-CN")
+	if info.NameZh != l.PollutantName("o3_8h") {
+		t.Errorf("o3_8h NameZh should be localized, but %s", info.NameZh)
+	}
+	if info.Name != "O₃ 8-hour" || info.NameZh != "臭氧8小时平均" || info.Species.NameZh != "臭氧" {
+		t.Errorf("o3_8h names should be set, but %s %s %s", info.Name, info.NameZh, info.Species.NameZh)
+	}
+	if info.Units["epa"] != UnitPpm || info.Units["mep"] != UnitUgm3 {
+		t.Errorf("o3_8h should be ppm under epa and µg/m³ under mep, but %v", info.Units)
+	}
+
+	info, _ = LookupPollutant("pm25_24h")
+	if info.Species.MolecularWeight != 0 || info.Species.Formula != "PM2.5" {
+		t.Errorf("pm25 should have no molecular weight, but %+v", info.Species)
+	}
+	if _, err = LookupPollutant("pm25_48h"); err == nil {
+		t.Error("unknown tag should fail with error")
+	}
+	if _, err = LookupSpecies("nh3"); err == nil {
+		t.Error("unknown species should fail with error")
+	}
+
+	// standards registered at runtime are included
+	RegisterStandard(NewPercentStandard("test-registry", map[string]float64{"nh3_24h": 100}, nil))
+	defer delete(standards, "test-registry")
+	info, err = LookupPollutant("nh3_24h")
+	if err != nil || info.Species.ID != "nh3" || info.AveragingHours != 24 || info.Units["test-registry"] != "" {
+		t.Errorf("nh3_24h should be registered, but %+v %v", info, err)
+	}
+}
+
+func TestPollutantTags(t *testing.T) {
+	tags, err := PollutantTags("mep")
+	if err != nil || len(tags) != 10 || tags[0] != "co_1h" {
+		t.Errorf("mep should have 10 sorted tags, but %v %v", tags, err)
+	}
+	if _, err = PollutantTags("unknown"); err == nil {
+		t.Error("unknown standard should fail with error")
+	}
+}
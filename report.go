@@ -0,0 +1,236 @@
+package aqi
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MepReportMode is the kind of a MEP report
+type MepReportMode int
+
+const (
+	// MepHourlyReport reports 1h concentrations of gases and the 24h
+	// sliding averages of PM
+	MepHourlyReport MepReportMode = iota
+	// MepDailyReport reports 24h averages of a natural day, the daily max
+	// 1h O3 and the daily max 8h sliding O3
+	MepDailyReport
+)
+
+// valid data requirements of GB3095-2012 and HJ633-2012
+const (
+	Mep24HMinHours   = 20
+	Mep8HMinHours    = 6
+	MepDailyO38HMin  = 14
+	MepSO21HReported = 800
+	MepO38HReported  = 800
+)
+
+func (mode MepReportMode) String() string {
+	if mode == MepDailyReport {
+		return "daily"
+	}
+	return "hourly"
+}
+
+func (mode MepReportMode) MarshalText() ([]byte, error) {
+	return marshalEnum("report mode", int(mode), 2, mode.String())
+}
+
+func (mode *MepReportMode) UnmarshalText(text []byte) error {
+	v, err := unmarshalEnum("report mode", text, 2, func(i int) string { return MepReportMode(i).String() })
+	if err == nil {
+		*mode = MepReportMode(v)
+	}
+	return err
+}
+
+// Pollutants returns the pollutant tags reported by the mode
+func (mode MepReportMode) Pollutants() []string {
+	if mode == MepDailyReport {
+		return append([]string{}, mepDailyPollutants...)
+	}
+	return append([]string{}, mepHourlyPollutants...)
+}
+
+// MepReport is a row of an hourly or daily MEP report. Time is the
+// beginning of the hour or the day. Concentrations are rounded as
+// published, integer µg/m³ and CO to 0.1 mg/m³
+type MepReport struct {
+	Station           string             `json:"station"`
+	Time              time.Time          `json:"time"`
+	Mode              MepReportMode      `json:"mode"`
+	Concentrations    map[string]float64 `json:"concentrations"`
+	IAQIs             map[string]int     `json:"iaqis"`
+	AQI               int                `json:"aqi"`
+	PrimaryPollutants []string           `json:"primary_pollutants"`
+	Category          Category           `json:"category"`
+}
+
+// roundReported rounds a concentration as it is published
+func roundReported(tag string, v float64) float64 {
+	if strings.HasPrefix(tag, "co_") {
+		return Round(v, 1)
+	}
+	return Round(v, 0)
+}
+
+// NewMepHourlyReport reports the hour of at of a station
+func NewMepHourlyReport(station *Station, at time.Time) (MepReport, error) {
+	station.mu.RLock()
+	defer station.mu.RUnlock()
+	hour := hourOf(at)
+	concentrations := make(map[string]float64)
+	for _, tag := range append(MepHourlyReport.Pollutants(), "so2_24h") {
+		window, _ := AveragingHours(tag)
+		hourly := station.hourly(species(tag), hour, window)
+		if hourly == nil {
+			continue
+		}
+		if _, err := sanitizeHourly(MepStandard, tag, hourly); err != nil {
+			return MepReport{}, errors.New(tag + ": " + err.Error())
+		}
+		if v, err := RollingAverage(hourly, window, MinValidHours(window)); err == nil {
+			concentrations[tag] = v
+		}
+	}
+	// so2_24h is only reported when so2_1h is over the calculable max
+	if v, ok := concentrations["so2_1h"]; !ok || v <= MepSO21HReported {
+		delete(concentrations, "so2_24h")
+	}
+	return newMepReport(station.ID, timeOfHour(hour).In(at.Location()), MepHourlyReport, concentrations)
+}
+
+// NewMepDailyReport reports the natural day of day, in its location, of a
+// station. 8h O3 averages of the day end at 8:00 to 24:00
+func NewMepDailyReport(station *Station, day time.Time) (MepReport, error) {
+	station.mu.RLock()
+	defer station.mu.RUnlock()
+	start := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	last := hourOf(start) + 23
+	concentrations := make(map[string]float64)
+	for _, tag := range []string{"so2_24h", "no2_24h", "co_24h", "pm10_24h", "pm25_24h"} {
+		if hourly := station.hourly(species(tag), last, 24); hourly != nil {
+			if _, err := sanitizeHourly(MepStandard, tag, hourly); err != nil {
+				return MepReport{}, errors.New(tag + ": " + err.Error())
+			}
+			if v, err := RollingAverage(hourly, 24, MinValidHours(24)); err == nil {
+				concentrations[tag] = v
+			}
+		}
+	}
+	if hourly := station.hourly("o3", last, 24); hourly != nil {
+		if _, err := sanitizeHourly(MepStandard, "o3_1h", hourly); err != nil {
+			return MepReport{}, errors.New("o3_1h: " + err.Error())
+		}
+		max1h := math.NaN()
+		for _, v := range hourly {
+			if !math.IsNaN(v) && !(v <= max1h) {
+				max1h = v
+			}
+		}
+		if !math.IsNaN(max1h) {
+			concentrations["o3_1h"] = max1h
+		}
+		// hourly is most recent first, the window ending at 24:00 starts
+		// at index 0 and the one ending at 8:00 at index 16
+		max8h, windows := math.NaN(), 0
+		for i := 0; i <= 16; i++ {
+			if v, err := RollingAverage(hourly[i:], 8, MinValidHours(8)); err == nil {
+				windows++
+				if !(v <= max8h) {
+					max8h = v
+				}
+			}
+		}
+		if windows >= MepDailyO38HMin {
+			concentrations["o3_8h"] = max8h
+		}
+	}
+	return newMepReport(station.ID, start, MepDailyReport, concentrations)
+}
+
+func newMepReport(id string, at time.Time, mode MepReportMode, concentrations map[string]float64) (MepReport, error) {
+	report := MepReport{
+		Station:        id,
+		Time:           at,
+		Mode:           mode,
+		Concentrations: make(map[string]float64),
+		IAQIs:          make(map[string]int),
+	}
+	for tag, v := range concentrations {
+		v = roundReported(tag, v)
+		report.Concentrations[tag] = v
+		// so2_1h and o3_8h over 800 are reported by so2_24h and o3_1h, see
+		// GetHourlyIAQI and GetDailyIAQI
+		if tag == "so2_1h" && v > MepSO21HReported || tag == "o3_8h" && v > MepO38HReported {
+			continue
+		}
+		iaqi, err := GetMepIAQI(tag, v)
+		if err != nil {
+			return report, errors.New(tag + ": " + err.Error())
+		}
+		report.IAQIs[tag] = iaqi
+	}
+	if len(report.IAQIs) == 0 {
+		return report, errors.New("No concentration")
+	}
+	report.AQI = MepStandard.AQI(report.IAQIs)
+	report.PrimaryPollutants = ResponsiblePollutants(MepStandard, report.IAQIs)
+	report.Category, _ = GetCategory(MepStandard, report.AQI)
+	return report, nil
+}
+
+// WriteMepReports writes reports of a mode in CSV, with the concentration
+// and IAQI of every reported pollutant, the AQI, primary pollutants and
+// category. Reports of another mode fail
+func WriteMepReports(w io.Writer, mode MepReportMode, reports []MepReport) error {
+	writer := csv.NewWriter(w)
+	tags := mode.Pollutants()
+	if mode == MepHourlyReport {
+		tags = append(tags, "so2_24h")
+	}
+	header := []string{"station", "time"}
+	for _, tag := range tags {
+		header = append(header, tag, tag+"_iaqi")
+	}
+	header = append(header, "aqi", "primary_pollutants", "level", "category")
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	layout := "2006-01-02 15:04"
+	if mode == MepDailyReport {
+		layout = "2006-01-02"
+	}
+	for _, report := range reports {
+		if report.Mode != mode {
+			return errors.New("Report of " + report.Mode.String() + " mode")
+		}
+		row := []string{report.Station, report.Time.Format(layout)}
+		for _, tag := range tags {
+			v, ok := report.Concentrations[tag]
+			if !ok {
+				row = append(row, "", "")
+				continue
+			}
+			row = append(row, strconv.FormatFloat(v, 'f', -1, 64))
+			if iaqi, ok := report.IAQIs[tag]; ok {
+				row = append(row, strconv.Itoa(iaqi))
+			} else {
+				row = append(row, "")
+			}
+		}
+		row = append(row, strconv.Itoa(report.AQI), strings.Join(report.PrimaryPollutants, " "),
+			strconv.Itoa(report.Category.Level), report.Category.Name)
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
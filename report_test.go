@@ -0,0 +1,160 @@
+package aqi
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+var reportLocation = time.FixedZone("CST", 8*3600)
+
+// newReportStation observes 2013-01-15 in CST, o3 rises 5 µg/m³ per hour
+func newReportStation(t *testing.T) *Station {
+	station := NewStation("1001", 2)
+	start := time.Date(2013, 1, 15, 0, 0, 0, 0, reportLocation)
+	for hour := 0; hour < 24; hour++ {
+		at := start.Add(time.Duration(hour) * time.Hour)
+		values := map[string]float64{"pm25": 82, "so2": 10, "no2": 20, "co": 1.04, "o3": float64(5 * hour)}
+		// 19 valid hours of pm10
+		if hour >= 5 {
+			values["pm10"] = 113
+		}
+		for pollutant, v := range values {
+			if err := station.Add(Observation{Time: at, Pollutant: pollutant, Value: v}); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	return station
+}
+
+func TestNewMepDailyReport(t *testing.T) {
+	station := newReportStation(t)
+	report, err := NewMepDailyReport(station, time.Date(2013, 1, 15, 12, 0, 0, 0, reportLocation))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.Time.Equal(time.Date(2013, 1, 15, 0, 0, 0, 0, reportLocation)) || report.Mode != MepDailyReport {
+		t.Errorf("report should be daily of 2013-01-15, but %v %v", report.Mode, report.Time)
+	}
+
+	type Seed struct {
+		Tag           string
+		Concentration float64
+		IAQI          int
+	}
+	seeds := []Seed{
+		{"so2_24h", 10, 10},
+		{"no2_24h", 20, 25},
+		{"co_24h", 1, 25},
+		{"o3_1h", 115, 36},
+		{"o3_8h", 98, 49},
+		{"pm25_24h", 82, 109},
+	}
+	for _, seed := range seeds {
+		if v := report.Concentrations[seed.Tag]; v != seed.Concentration {
+			t.Errorf("%s should be %v, but %v", seed.Tag, seed.Concentration, v)
+		}
+		if v := report.IAQIs[seed.Tag]; v != seed.IAQI {
+			t.Errorf("%s IAQI should be %d, but %d", seed.Tag, seed.IAQI, v)
+		}
+	}
+	if _, ok := report.Concentrations["pm10_24h"]; ok {
+		t.Error("pm10_24h of 19 valid hours should not be reported")
+	}
+	if _, ok := report.Concentrations["so2_1h"]; ok {
+		t.Error("so2_1h should not be reported daily")
+	}
+	if report.AQI != 109 || report.PrimaryPollutants[0] != "pm25_24h" || report.Category.Level != 3 {
+		t.Errorf("daily AQI should be 109 of pm25_24h, but %d %v %v", report.AQI, report.PrimaryPollutants, report.Category)
+	}
+
+	if _, err = NewMepDailyReport(station, time.Date(2013, 1, 16, 0, 0, 0, 0, reportLocation)); err == nil {
+		t.Error("day without observation should fail with error")
+	}
+}
+
+func TestNewMepHourlyReport(t *testing.T) {
+	station := newReportStation(t)
+	at := time.Date(2013, 1, 15, 23, 30, 0, 0, reportLocation)
+	report, err := NewMepHourlyReport(station, at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]float64{"so2_1h": 10, "no2_1h": 20, "co_1h": 1, "o3_1h": 115, "pm25_24h": 82}
+	for tag, v := range expected {
+		if report.Concentrations[tag] != v {
+			t.Errorf("%s should be %v, but %v", tag, v, report.Concentrations[tag])
+		}
+	}
+	for _, tag := range []string{"so2_24h", "no2_24h", "co_24h", "o3_8h", "pm10_24h"} {
+		if _, ok := report.Concentrations[tag]; ok {
+			t.Errorf("%s should not be reported hourly", tag)
+		}
+	}
+	if report.AQI != 109 {
+		t.Errorf("hourly AQI should be 109, but %d", report.AQI)
+	}
+
+	// 1 valid hour of pm25
+	report, err = NewMepHourlyReport(station, time.Date(2013, 1, 15, 0, 0, 0, 0, reportLocation))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := report.Concentrations["pm25_24h"]; ok {
+		t.Error("pm25_24h of 1 valid hour should not be reported")
+	}
+
+	// so2_1h over 800 is reported by so2_24h
+	station.Add(Observation{Time: at, Pollutant: "so2", Value: 900})
+	report, err = NewMepHourlyReport(station, at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := report.IAQIs["so2_1h"]; ok || report.Concentrations["so2_1h"] != 900 {
+		t.Errorf("so2_1h 900 should be reported without IAQI, but %v", report.IAQIs)
+	}
+	if report.Concentrations["so2_24h"] != 47 || report.IAQIs["so2_24h"] != 47 {
+		t.Errorf("so2_24h should be 47 of IAQI 47, but %v %v", report.Concentrations["so2_24h"], report.IAQIs["so2_24h"])
+	}
+
+	// negatives follow the MEP sanitation policy like the station AQI
+	station.Add(Observation{Time: at, Pollutant: "no2", Value: -3})
+	if report, err = NewMepHourlyReport(station, at); err != nil || report.Concentrations["no2_1h"] != 0 {
+		t.Errorf("no2 -3 should be clamped to 0, but %v %v", report.Concentrations["no2_1h"], err)
+	}
+	station.Add(Observation{Time: at, Pollutant: "no2", Value: -500})
+	if _, err = NewMepHourlyReport(station, at); err == nil {
+		t.Error("no2 -500 should be rejected")
+	}
+}
+
+func TestWriteMepReports(t *testing.T) {
+	station := newReportStation(t)
+	report, err := NewMepDailyReport(station, time.Date(2013, 1, 15, 0, 0, 0, 0, reportLocation))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err = WriteMepReports(&buf, MepDailyReport, []MepReport{report}); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	expected := []string{
+		"station,time,so2_24h,so2_24h_iaqi,no2_24h,no2_24h_iaqi,co_24h,co_24h_iaqi,o3_1h,o3_1h_iaqi,o3_8h,o3_8h_iaqi,pm10_24h,pm10_24h_iaqi,pm25_24h,pm25_24h_iaqi,aqi,primary_pollutants,level,category",
+		"1001,2013-01-15,10,10,20,25,1,25,115,36,98,49,,,82,109,109,pm25_24h,3,Lightly Polluted",
+	}
+	if len(lines) != len(expected) {
+		t.Fatalf("report should be %d lines, but %d", len(expected), len(lines))
+	}
+	for i, line := range expected {
+		if lines[i] != line {
+			t.Errorf("line %d should be\n%s\nbut\n%s", i, line, lines[i])
+		}
+	}
+
+	if err = WriteMepReports(&buf, MepHourlyReport, []MepReport{report}); err == nil {
+		t.Error("daily report should fail with error in hourly mode")
+	}
+}
@@ -0,0 +1,132 @@
+package aqi
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// RoundingMode is how a value is rounded to a number of decimal digits
+type RoundingMode int
+
+const (
+	// RoundTruncate discards the extra digits, toward zero
+	RoundTruncate RoundingMode = iota
+	// RoundHalfUp rounds half away from zero
+	RoundHalfUp
+	// RoundCeil rounds toward positive infinity
+	RoundCeil
+	// RoundHalfEven rounds half to the even digit(banker's rounding)
+	RoundHalfEven
+)
+
+func (mode RoundingMode) String() string {
+	switch mode {
+	case RoundTruncate:
+		return "truncate"
+	case RoundHalfUp:
+		return "half-up"
+	case RoundCeil:
+		return "ceil"
+	case RoundHalfEven:
+		return "half-even"
+	}
+	return "RoundingMode(" + strconv.Itoa(int(mode)) + ")"
+}
+
+func (mode RoundingMode) MarshalText() ([]byte, error) {
+	return marshalEnum("rounding mode", int(mode), 4, mode.String())
+}
+
+func (mode *RoundingMode) UnmarshalText(text []byte) error {
+	v, err := unmarshalEnum("rounding mode", text, 4, func(i int) string { return RoundingMode(i).String() })
+	if err == nil {
+		*mode = RoundingMode(v)
+	}
+	return err
+}
+
+// RoundDecimal rounds v to digits(>= 0) decimal digits with exact decimal
+// arithmetic on the shortest decimal representation of v, so 0.285 is
+// 0.285 rather than the binary 0.28499999999999998
+func RoundDecimal(v float64, digits int, mode RoundingMode) float64 {
+	if math.IsNaN(v) || math.IsInf(v, 0) || digits < 0 {
+		return v
+	}
+	negative := v < 0
+	s := strconv.FormatFloat(math.Abs(v), 'f', -1, 64)
+	intPart, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, frac = s[:i], s[i+1:]
+	}
+	if len(frac) <= digits {
+		return v
+	}
+	kept, rest := frac[:digits], frac[digits:]
+	restNonZero := strings.Trim(rest, "0") != ""
+
+	up := false
+	switch mode {
+	case RoundHalfUp:
+		up = rest[0] >= '5'
+	case RoundCeil:
+		up = !negative && restNonZero
+	case RoundHalfEven:
+		last := intPart[len(intPart)-1]
+		if digits > 0 {
+			last = kept[digits-1]
+		}
+		up = rest[0] > '5' || rest[0] == '5' && (strings.Trim(rest[1:], "0") != "" || (last-'0')%2 == 1)
+	}
+
+	n, _ := new(big.Int).SetString(intPart+kept, 10)
+	if up {
+		n.Add(n, big.NewInt(1))
+	}
+	digitsStr := n.String()
+	if digits > 0 {
+		if len(digitsStr) <= digits {
+			digitsStr = strings.Repeat("0", digits-len(digitsStr)+1) + digitsStr
+		}
+		digitsStr = digitsStr[:len(digitsStr)-digits] + "." + digitsStr[len(digitsStr)-digits:]
+	}
+	result, _ := strconv.ParseFloat(digitsStr, 64)
+	if negative {
+		return -result
+	}
+	return result
+}
+
+// RoundingPolicy is how a standard rounds concentrations and indexes.
+// InputDigits are the decimal digits concentrations of a pollutant are
+// truncated to, pollutants without an entry are not truncated. The
+// interpolated index is rounded half-up to IndexDigits(> 0) first, then to an
+// integer by Output
+type RoundingPolicy struct {
+	InputDigits map[string]int `json:"input_digits,omitempty"`
+	IndexDigits int            `json:"index_digits,omitempty"`
+	Output      RoundingMode   `json:"output"`
+}
+
+// Rounder is implemented by standards declaring their rounding policy
+type Rounder interface {
+	RoundingPolicy() RoundingPolicy
+}
+
+// Input truncates the concentration of a pollutant
+func (policy RoundingPolicy) Input(pollutant string, concentration float64) float64 {
+	digits, ok := policy.InputDigits[pollutant]
+	if !ok {
+		return concentration
+	}
+	return RoundDecimal(concentration, digits, RoundTruncate)
+}
+
+// Index rounds an interpolated index value to an integer
+func (policy RoundingPolicy) Index(v float64) int {
+	if policy.IndexDigits > 0 {
+		v = RoundDecimal(v, policy.IndexDigits, RoundHalfUp)
+	}
+	return int(RoundDecimal(v, 0, policy.Output))
+}
@@ -0,0 +1,99 @@
+package aqi
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRoundDecimal(t *testing.T) {
+	type Seed struct {
+		V      float64
+		Digits int
+		Mode   RoundingMode
+		Expect float64
+	}
+	seeds := []Seed{
+		{10 / 3.0, 2, RoundTruncate, 3.33},
+		{2.6, 0, RoundTruncate, 2},
+		{-2.6, 0, RoundTruncate, -2},
+		{0.08742, 3, RoundTruncate, 0.087},
+		// 0.285 is 0.28499999999999998 in binary
+		{0.285, 2, RoundHalfUp, 0.29},
+		{1.005, 2, RoundHalfUp, 1.01},
+		{99.5, 0, RoundHalfUp, 100},
+		{99.49, 0, RoundHalfUp, 99},
+		{-2.5, 0, RoundHalfUp, -3},
+		{108.75, 0, RoundCeil, 109},
+		{108, 0, RoundCeil, 108},
+		{108.0000001, 0, RoundCeil, 109},
+		{-2.5, 0, RoundCeil, -2},
+		{2.5, 0, RoundHalfEven, 2},
+		{3.5, 0, RoundHalfEven, 4},
+		{2.51, 0, RoundHalfEven, 3},
+		{0.125, 2, RoundHalfEven, 0.12},
+		{0.135, 2, RoundHalfEven, 0.14},
+		{9.999, 2, RoundHalfUp, 10},
+		{0.005, 2, RoundHalfUp, 0.01},
+		{0.004, 2, RoundCeil, 0.01},
+		{1.5, 3, RoundTruncate, 1.5},
+	}
+	for _, seed := range seeds {
+		if v := RoundDecimal(seed.V, seed.Digits, seed.Mode); v != seed.Expect {
+			t.Errorf("%v %s to %d digits should be %v, but %v", seed.V, seed.Mode, seed.Digits, seed.Expect, v)
+		}
+	}
+	if v := RoundDecimal(math.NaN(), 0, RoundHalfUp); !math.IsNaN(v) {
+		t.Errorf("NaN should be kept, but %v", v)
+	}
+}
+
+func TestRoundingPolicy(t *testing.T) {
+	type Seed struct {
+		Policy RoundingPolicy
+		Index  float64
+		Expect int
+	}
+	seeds := []Seed{
+		{EpaRoundingPolicy, 99.5, 100},
+		{EpaRoundingPolicy, 99.49, 99},
+		// MEP rounds to one decimal first
+		{MepRoundingPolicy, 108.04, 108},
+		{MepRoundingPolicy, 108.05, 109},
+		{MepRoundingPolicy, 108.75, 109},
+		{RoundingPolicy{Output: RoundTruncate}, 108.99, 108},
+		{RoundingPolicy{Output: RoundHalfEven}, 108.5, 108},
+	}
+	for _, seed := range seeds {
+		if v := seed.Policy.Index(seed.Index); v != seed.Expect {
+			t.Errorf("%v index should be %d, but %d", seed.Index, seed.Expect, v)
+		}
+	}
+
+	if v := EpaRoundingPolicy.Input("o3_8h", 0.08742); v != 0.087 {
+		t.Errorf("o3_8h should be truncated to 0.087, but %v", v)
+	}
+	if v := EpaRoundingPolicy.Input("pm25_24h", 40.99); v != 40.9 {
+		t.Errorf("pm25_24h should be truncated to 40.9, but %v", v)
+	}
+	if v := MepRoundingPolicy.Input("pm25_24h", 40.99); v != 40.99 {
+		t.Errorf("mep pm25_24h should not be truncated, but %v", v)
+	}
+}
+
+func TestStandardRoundingPolicy(t *testing.T) {
+	for _, name := range StandardNames() {
+		std, _ := GetStandard(name)
+		if _, ok := std.(Rounder); !ok {
+			t.Errorf("%s should declare its rounding policy", name)
+		}
+	}
+
+	std := NewPercentStandard("custom", map[string]float64{"pm25_24h": 25}, nil)
+	if v, _ := std.IAQI("pm25_24h", 25.2); v != 101 {
+		t.Errorf("custom pm25_24h IAQI should be 101, but %d", v)
+	}
+	std.SetRoundingPolicy(RoundingPolicy{InputDigits: map[string]int{"pm25_24h": 0}, Output: RoundTruncate})
+	if v, _ := std.IAQI("pm25_24h", 25.2); v != 100 {
+		t.Errorf("custom truncated pm25_24h IAQI should be 100, but %d", v)
+	}
+}
@@ -0,0 +1,204 @@
+package aqi
+
+import (
+	"errors"
+	"math"
+	"strconv"
+)
+
+// errors of concentrations sanitized by a SanitationPolicy
+var (
+	ErrInvalidConcentration = errors.New("Invalid concentration value")
+	ErrMissingConcentration = errors.New("Missing concentration value")
+)
+
+// SanitationMode is how a standard treats negative, NaN and infinite
+// concentrations
+type SanitationMode int
+
+const (
+	// SanitizeClamp clamps negatives within the tolerance to 0 and rejects
+	// the other invalid values
+	SanitizeClamp SanitationMode = iota
+	// SanitizeReject rejects every invalid value, the IAQI functions fail
+	// with -4
+	SanitizeReject
+	// SanitizeMissing treats invalid values as missing, the IAQI functions
+	// fail with -1 and Calculate leaves them out
+	SanitizeMissing
+)
+
+func (mode SanitationMode) String() string {
+	switch mode {
+	case SanitizeClamp:
+		return "clamp"
+	case SanitizeReject:
+		return "reject"
+	case SanitizeMissing:
+		return "missing"
+	}
+	return "SanitationMode(" + strconv.Itoa(int(mode)) + ")"
+}
+
+func (mode SanitationMode) MarshalText() ([]byte, error) {
+	return marshalEnum("sanitation mode", int(mode), 3, mode.String())
+}
+
+func (mode *SanitationMode) UnmarshalText(text []byte) error {
+	v, err := unmarshalEnum("sanitation mode", text, 3, func(i int) string { return SanitationMode(i).String() })
+	if err == nil {
+		*mode = SanitationMode(v)
+	}
+	return err
+}
+
+// SanitationPolicy is how a standard sanitizes concentrations. Tolerance is
+// the largest magnitude of negatives clamped to 0 by SanitizeClamp,
+// Tolerances overrides it by the unit of the pollutant
+type SanitationPolicy struct {
+	Mode       SanitationMode
+	Tolerance  float64
+	Tolerances map[string]float64
+}
+
+// DefaultTolerances are the tolerances of DefaultSanitationPolicy, about
+// the noise of instruments near zero
+var DefaultTolerances = map[string]float64{
+	UnitUgm3: 5,
+	UnitMgm3: 0.1,
+	UnitPpb:  5,
+	UnitPpm:  0.01,
+}
+
+// DefaultSanitationPolicy clamps small negatives to 0 by DefaultTolerances
+// and rejects larger negatives, negatives of unknown units, NaN and
+// infinities
+var DefaultSanitationPolicy = SanitationPolicy{Mode: SanitizeClamp, Tolerances: DefaultTolerances}
+
+// Sanitizer is implemented by standards declaring their sanitation policy
+type Sanitizer interface {
+	SanitationPolicy() SanitationPolicy
+}
+
+// Issue is what is wrong with a concentration
+type Issue int
+
+const (
+	IssueNone Issue = iota
+	IssueNegative
+	IssueNaN
+	IssueInf
+)
+
+func (issue Issue) String() string {
+	switch issue {
+	case IssueNone:
+		return "none"
+	case IssueNegative:
+		return "negative"
+	case IssueNaN:
+		return "nan"
+	case IssueInf:
+		return "inf"
+	}
+	return "Issue(" + strconv.Itoa(int(issue)) + ")"
+}
+
+func (issue Issue) MarshalText() ([]byte, error) {
+	return marshalEnum("issue", int(issue), 4, issue.String())
+}
+
+func (issue *Issue) UnmarshalText(text []byte) error {
+	v, err := unmarshalEnum("issue", text, 4, func(i int) string { return Issue(i).String() })
+	if err == nil {
+		*issue = Issue(v)
+	}
+	return err
+}
+
+// SanitationAction is what a sanitation policy did to a concentration
+type SanitationAction int
+
+const (
+	ActionNone SanitationAction = iota
+	ActionClamped
+	ActionMissing
+	ActionRejected
+)
+
+func (action SanitationAction) String() string {
+	switch action {
+	case ActionNone:
+		return "none"
+	case ActionClamped:
+		return "clamped"
+	case ActionMissing:
+		return "missing"
+	case ActionRejected:
+		return "rejected"
+	}
+	return "SanitationAction(" + strconv.Itoa(int(action)) + ")"
+}
+
+func (action SanitationAction) MarshalText() ([]byte, error) {
+	return marshalEnum("sanitation action", int(action), 4, action.String())
+}
+
+func (action *SanitationAction) UnmarshalText(text []byte) error {
+	v, err := unmarshalEnum("sanitation action", text, 4, func(i int) string { return SanitationAction(i).String() })
+	if err == nil {
+		*action = SanitationAction(v)
+	}
+	return err
+}
+
+// Diagnostic is the issue of a concentration and the action taken
+type Diagnostic struct {
+	Issue  Issue            `json:"issue"`
+	Action SanitationAction `json:"action"`
+}
+
+// tolerance returns the tolerance of a unit
+func (policy SanitationPolicy) tolerance(unit string) float64 {
+	if tolerance, ok := policy.Tolerances[unit]; ok {
+		return tolerance
+	}
+	return policy.Tolerance
+}
+
+// Sanitize returns the concentration to index and its diagnostic, err is
+// ErrMissingConcentration or ErrInvalidConcentration when it can't be
+// indexed. unit is the unit of the concentration
+func (policy SanitationPolicy) Sanitize(concentration float64, unit string) (float64, Diagnostic, error) {
+	var issue Issue
+	switch {
+	case math.IsNaN(concentration):
+		issue = IssueNaN
+	case math.IsInf(concentration, 0):
+		issue = IssueInf
+	case concentration < 0:
+		issue = IssueNegative
+	default:
+		return concentration, Diagnostic{}, nil
+	}
+	if policy.Mode == SanitizeMissing {
+		return concentration, Diagnostic{issue, ActionMissing}, ErrMissingConcentration
+	}
+	if policy.Mode == SanitizeClamp && issue == IssueNegative && -concentration <= policy.tolerance(unit) {
+		return 0, Diagnostic{issue, ActionClamped}, nil
+	}
+	return concentration, Diagnostic{issue, ActionRejected}, ErrInvalidConcentration
+}
+
+// apply sanitizes a concentration of a unit for the IAQI functions, iaqi is
+// the sentinel of a concentration failing with err
+func (policy SanitationPolicy) apply(concentration float64, unit string) (float64, float64, error) {
+	concentration, _, err := policy.Sanitize(concentration, unit)
+	switch err {
+	case ErrMissingConcentration:
+		return concentration, -1, err
+	case ErrInvalidConcentration:
+		return concentration, -4, err
+	}
+	return concentration, 0, nil
+}
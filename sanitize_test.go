@@ -0,0 +1,123 @@
+package aqi
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSanitationPolicy(t *testing.T) {
+	type Seed struct {
+		Policy        SanitationPolicy
+		Concentration float64
+		Unit          string
+		Expect        float64
+		Diagnostic    Diagnostic
+		Err           error
+	}
+	clamp := SanitationPolicy{Mode: SanitizeClamp, Tolerance: 2}
+	seeds := []Seed{
+		{clamp, 35.5, "", 35.5, Diagnostic{}, nil},
+		{clamp, 0, "", 0, Diagnostic{}, nil},
+		{clamp, -1.5, "", 0, Diagnostic{IssueNegative, ActionClamped}, nil},
+		{clamp, -2, "", 0, Diagnostic{IssueNegative, ActionClamped}, nil},
+		{clamp, -2.1, "", -2.1, Diagnostic{IssueNegative, ActionRejected}, ErrInvalidConcentration},
+		{clamp, math.Inf(1), "", math.Inf(1), Diagnostic{IssueInf, ActionRejected}, ErrInvalidConcentration},
+		{SanitationPolicy{Mode: SanitizeReject}, -0.1, "", -0.1, Diagnostic{IssueNegative, ActionRejected}, ErrInvalidConcentration},
+		{SanitationPolicy{Mode: SanitizeMissing}, -0.1, "", -0.1, Diagnostic{IssueNegative, ActionMissing}, ErrMissingConcentration},
+		{DefaultSanitationPolicy, -3, UnitUgm3, 0, Diagnostic{IssueNegative, ActionClamped}, nil},
+		{DefaultSanitationPolicy, -500, UnitUgm3, -500, Diagnostic{IssueNegative, ActionRejected}, ErrInvalidConcentration},
+		{DefaultSanitationPolicy, -0.005, UnitPpm, 0, Diagnostic{IssueNegative, ActionClamped}, nil},
+		{DefaultSanitationPolicy, -3, UnitPpm, -3, Diagnostic{IssueNegative, ActionRejected}, ErrInvalidConcentration},
+		{DefaultSanitationPolicy, -0.1, "", -0.1, Diagnostic{IssueNegative, ActionRejected}, ErrInvalidConcentration},
+		{DefaultSanitationPolicy, math.Inf(-1), UnitUgm3, math.Inf(-1), Diagnostic{IssueInf, ActionRejected}, ErrInvalidConcentration},
+	}
+	for _, seed := range seeds {
+		v, diagnostic, err := seed.Policy.Sanitize(seed.Concentration, seed.Unit)
+		if v != seed.Expect || diagnostic != seed.Diagnostic || err != seed.Err {
+			t.Errorf("%s %v should be %v %v %v, but %v %v %v", seed.Policy.Mode, seed.Concentration,
+				seed.Expect, seed.Diagnostic, seed.Err, v, diagnostic, err)
+		}
+	}
+	_, diagnostic, err := clamp.Sanitize(math.NaN(), "")
+	if diagnostic != (Diagnostic{IssueNaN, ActionRejected}) || err != ErrInvalidConcentration {
+		t.Errorf("NaN should be rejected, but %v %v", diagnostic, err)
+	}
+}
+
+func TestSanitizedIAQI(t *testing.T) {
+	defer func(epa, mep SanitationPolicy) {
+		EpaSanitationPolicy, MepSanitationPolicy = epa, mep
+	}(EpaSanitationPolicy, MepSanitationPolicy)
+
+	// small negatives are clamped by default, MEP no longer divides by 0
+	for _, f := range []func(string, float64) (int, error){GetEpaIAQI, GetMepIAQI} {
+		if v, err := f("pm25_24h", -3); v != 0 || err != nil {
+			t.Errorf("negative should be 0, but %d %v", v, err)
+		}
+		if v, err := f("pm25_24h", -500); v != -4 || err != ErrInvalidConcentration {
+			t.Errorf("negative beyond the tolerance should fail with -4, but %d %v", v, err)
+		}
+		if v, err := f("pm25_24h", math.NaN()); v != -4 || err != ErrInvalidConcentration {
+			t.Errorf("NaN should fail with -4, but %d %v", v, err)
+		}
+	}
+
+	EpaSanitationPolicy = SanitationPolicy{Mode: SanitizeReject}
+	MepSanitationPolicy = SanitationPolicy{Mode: SanitizeMissing}
+	if v, err := GetEpaIAQI("pm25_24h", -3); v != -4 || err != ErrInvalidConcentration {
+		t.Errorf("rejected negative should fail with -4, but %d %v", v, err)
+	}
+	if v, err := GetMepIAQI("pm25_24h", -3); v != -1 || err != ErrMissingConcentration {
+		t.Errorf("missing negative should fail with -1, but %d %v", v, err)
+	}
+	mep := &MepPollutant{PM25Pollutant24H: -3, PM10Pollutant24H: 120}
+	if v := mep.GetAQI(); v != 86 {
+		t.Errorf("missing pm25_24h should be left out of AQI 86, but %d", v)
+	}
+}
+
+func TestCalculateDiagnostics(t *testing.T) {
+	defer func(policy SanitationPolicy) { MepSanitationPolicy = policy }(MepSanitationPolicy)
+
+	concentrations := map[string]float64{"pm25_24h": -0.5, "pm10_24h": 120, "co_24h": math.NaN()}
+	if _, err := Calculate(MepStandard, concentrations); err == nil {
+		t.Error("NaN should fail with error")
+	}
+
+	MepSanitationPolicy = SanitationPolicy{Mode: SanitizeMissing}
+	result, err := CalculateFloat(MepStandard, concentrations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.IAQIs) != 1 || result.AQI != 86 || len(result.FloatIAQIs) != 1 {
+		t.Errorf("missing concentrations should be left out, but %v %v", result.IAQIs, result.FloatIAQIs)
+	}
+	if d := result.Diagnostics["co_24h"]; d != (Diagnostic{IssueNaN, ActionMissing}) {
+		t.Errorf("co_24h should be missing NaN, but %v", d)
+	}
+	if d := result.Diagnostics["pm25_24h"]; d != (Diagnostic{IssueNegative, ActionMissing}) {
+		t.Errorf("pm25_24h should be missing negative, but %v", d)
+	}
+	if _, ok := result.Diagnostics["pm10_24h"]; ok {
+		t.Error("valid pm10_24h should have no diagnostic")
+	}
+
+	custom := NewPercentStandard("custom", map[string]float64{"pm25_24h": 25}, nil)
+	custom.SetSanitationPolicy(SanitationPolicy{Mode: SanitizeClamp, Tolerance: 1})
+	customResult, err := Calculate(custom, map[string]float64{"pm25_24h": -0.5})
+	if err != nil || customResult.IAQIs["pm25_24h"] != 0 || customResult.Diagnostics["pm25_24h"].Action != ActionClamped {
+		t.Errorf("custom -0.5 should be clamped to 0, but %v %v", customResult, err)
+	}
+	if _, err = Calculate(custom, map[string]float64{"pm25_24h": -5}); err == nil {
+		t.Error("custom -5 should fail with error")
+	}
+}
+
+func TestStandardSanitationPolicy(t *testing.T) {
+	for _, name := range StandardNames() {
+		std, _ := GetStandard(name)
+		if _, ok := std.(Sanitizer); !ok {
+			t.Errorf("%s should declare its sanitation policy", name)
+		}
+	}
+}
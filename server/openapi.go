@@ -0,0 +1,218 @@
+package server
+
+import (
+	"sort"
+	"strings"
+
+	aqi "github.com/elvuel/go-aqi"
+)
+
+type object map[string]interface{}
+
+func ref(name string) object {
+	return object{"$ref": "#/components/schemas/" + name}
+}
+
+func jsonContent(schema object) object {
+	return object{"application/json": object{"schema": schema}}
+}
+
+func responses(ok object) object {
+	return object{
+		"200":     object{"description": "OK", "content": jsonContent(ok)},
+		"default": object{"description": "Error", "content": jsonContent(ref("Error"))},
+	}
+}
+
+func queryParameter(name, kind, description string) object {
+	return object{
+		"name":        name,
+		"in":          "query",
+		"required":    true,
+		"description": description,
+		"schema":      object{"type": kind},
+	}
+}
+
+// OpenAPI generates the OpenAPI 3 document of the API from the registered
+// standards
+func OpenAPI() map[string]interface{} {
+	names := aqi.StandardNames()
+	tags := make(map[string]bool)
+	supported := make([]string, 0, len(names))
+	for _, name := range names {
+		std, _ := aqi.GetStandard(name)
+		for _, pollutant := range std.Pollutants() {
+			tags[pollutant] = true
+		}
+		supported = append(supported, name+": "+strings.Join(std.Pollutants(), ", "))
+	}
+	pollutants := make([]string, 0, len(tags))
+	concentrations := object{}
+	for tag := range tags {
+		pollutants = append(pollutants, tag)
+		concentrations[tag] = object{"type": "number", "minimum": 0}
+	}
+	sort.Strings(pollutants)
+
+	standard := object{
+		"name":     "std",
+		"in":       "path",
+		"required": true,
+		"schema":   object{"type": "string", "enum": names},
+	}
+
+	return object{
+		"openapi": "3.0.3",
+		"info": object{
+			"title":   "AQI",
+			"version": aqi.Version,
+			"license": object{"name": aqi.Licence},
+		},
+		"paths": object{
+			"/standards": object{
+				"get": object{
+					"summary":   "List standards",
+					"responses": responses(object{"type": "array", "items": ref("Standard")}),
+				},
+			},
+			"/standards/{std}": object{
+				"get": object{
+					"summary":    "Get a standard",
+					"parameters": []object{standard},
+					"responses":  responses(ref("Standard")),
+				},
+			},
+			"/standards/{std}/breakpoints": object{
+				"get": object{
+					"summary": "Break point tables of a standard",
+					"parameters": []object{standard, object{
+						"name":   "pollutant",
+						"in":     "query",
+						"schema": object{"type": "string", "enum": pollutants},
+					}},
+					"responses": responses(object{
+						"type":                 "object",
+						"additionalProperties": object{"type": "array", "items": ref("Band")},
+					}),
+				},
+			},
+			"/standards/{std}/category": object{
+				"get": object{
+					"summary":    "Category of an index value",
+					"parameters": []object{standard, queryParameter("aqi", "integer", "index value")},
+					"responses":  responses(ref("Category")),
+				},
+			},
+			"/standards/{std}/inverse": object{
+				"get": object{
+					"summary": "Concentration of an individual index",
+					"parameters": []object{standard,
+						queryParameter("pollutant", "string", "pollutant tag"),
+						queryParameter("iaqi", "integer", "individual index value")},
+					"responses": responses(ref("Inverse")),
+				},
+			},
+			"/standards/{std}/iaqi": object{
+				"post": object{
+					"summary":     "Individual index of a pollutant",
+					"parameters":  []object{standard},
+					"requestBody": object{"required": true, "content": jsonContent(ref("IAQIRequest"))},
+					"responses":   responses(ref("IAQI")),
+				},
+			},
+			"/standards/{std}/aqi": object{
+				"post": object{
+					"summary":     "Composite index of concentrations keyed by pollutant tag",
+					"description": "Supported pollutants, " + strings.Join(supported, "; "),
+					"parameters":  []object{standard},
+					"requestBody": object{"required": true, "content": jsonContent(ref("Concentrations"))},
+					"responses":   responses(ref("AQI")),
+				},
+			},
+		},
+		"components": object{
+			"schemas": object{
+				"Error": object{
+					"type":       "object",
+					"properties": object{"error": object{"type": "string"}},
+				},
+				"Standard": object{
+					"type": "object",
+					"properties": object{
+						"name":       object{"type": "string"},
+						"pollutants": object{"type": "array", "items": object{"type": "string"}},
+						"categories": object{"type": "array", "items": ref("Category")},
+					},
+				},
+				"Category": object{
+					"type": "object",
+					"properties": object{
+						"level":       object{"type": "integer"},
+						"name":        object{"type": "string"},
+						"from":        object{"type": "integer"},
+						"to":          object{"type": "integer"},
+						"color_name":  object{"type": "string"},
+						"color":       object{"type": "string", "example": "#00E400"},
+						"description": object{"type": "string"},
+						"advice":      object{"type": "string"},
+					},
+				},
+				"Band": object{
+					"type": "object",
+					"properties": object{
+						"iaqi_from": object{"type": "number"},
+						"iaqi_to":   object{"type": "number"},
+						"from":      object{"type": "number"},
+						"to":        object{"type": "number"},
+					},
+				},
+				"Inverse": object{
+					"type": "object",
+					"properties": object{
+						"standard":      object{"type": "string"},
+						"pollutant":     object{"type": "string"},
+						"iaqi":          object{"type": "integer"},
+						"concentration": object{"type": "number"},
+					},
+				},
+				"IAQIRequest": object{
+					"type":     "object",
+					"required": []string{"pollutant", "concentration"},
+					"properties": object{
+						"pollutant":     object{"type": "string", "enum": pollutants},
+						"concentration": object{"type": "number", "minimum": 0},
+					},
+					"additionalProperties": false,
+				},
+				"IAQI": object{
+					"type": "object",
+					"properties": object{
+						"standard":      object{"type": "string"},
+						"pollutant":     object{"type": "string"},
+						"concentration": object{"type": "number"},
+						"iaqi":          object{"type": "integer"},
+						"category":      ref("Category"),
+					},
+				},
+				"Concentrations": object{
+					"type":                 "object",
+					"properties":           concentrations,
+					"additionalProperties": false,
+					"minProperties":        1,
+				},
+				"AQI": object{
+					"type": "object",
+					"properties": object{
+						"standard":               object{"type": "string"},
+						"concentrations":         ref("Concentrations"),
+						"iaqis":                  object{"type": "object", "additionalProperties": object{"type": "integer"}},
+						"aqi":                    object{"type": "integer"},
+						"responsible_pollutants": object{"type": "array", "items": object{"type": "string"}},
+						"category":               ref("Category"),
+					},
+				},
+			},
+		},
+	}
+}
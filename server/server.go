@@ -0,0 +1,340 @@
+// Package server serves AQI calculation over an HTTP JSON API
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	aqi "github.com/elvuel/go-aqi"
+)
+
+// maxBodyBytes limits request bodies
+const maxBodyBytes = 1 << 20
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type standardResponse struct {
+	Name       string             `json:"name"`
+	Pollutants []string           `json:"pollutants"`
+	Categories []categoryResponse `json:"categories,omitempty"`
+}
+
+type categoryResponse struct {
+	Level       int    `json:"level"`
+	Name        string `json:"name"`
+	From        int    `json:"from"`
+	To          int    `json:"to"`
+	ColorName   string `json:"color_name,omitempty"`
+	Color       string `json:"color"`
+	Description string `json:"description,omitempty"`
+	Advice      string `json:"advice,omitempty"`
+}
+
+type bandResponse struct {
+	IAQIFrom float64 `json:"iaqi_from"`
+	IAQITo   float64 `json:"iaqi_to"`
+	From     float64 `json:"from"`
+	To       float64 `json:"to"`
+}
+
+type iaqiRequest struct {
+	Pollutant     string   `json:"pollutant"`
+	Concentration *float64 `json:"concentration"`
+}
+
+type iaqiResponse struct {
+	Standard      string            `json:"standard"`
+	Pollutant     string            `json:"pollutant"`
+	Concentration float64           `json:"concentration"`
+	IAQI          int               `json:"iaqi"`
+	Category      *categoryResponse `json:"category,omitempty"`
+}
+
+type aqiResponse struct {
+	aqi.Result
+	Category *categoryResponse `json:"category,omitempty"`
+}
+
+type inverseResponse struct {
+	Standard      string  `json:"standard"`
+	Pollutant     string  `json:"pollutant"`
+	IAQI          int     `json:"iaqi"`
+	Concentration float64 `json:"concentration"`
+}
+
+// NewHandler returns the API handler of all registered standards
+//
+//	GET  /standards
+//	GET  /standards/{std}
+//	GET  /standards/{std}/breakpoints?pollutant=
+//	GET  /standards/{std}/category?aqi=
+//	GET  /standards/{std}/inverse?pollutant=&iaqi=
+//	POST /standards/{std}/iaqi   {"pollutant": "pm25_24h", "concentration": 64}
+//	POST /standards/{std}/aqi    {"pm25_24h": 64, "pm10_24h": 115}
+//	GET  /openapi.json
+func NewHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/standards", listStandards)
+	mux.HandleFunc("/standards/", routeStandard)
+	mux.HandleFunc("/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		if !allowMethod(w, r, "GET") {
+			return
+		}
+		writeJSON(w, http.StatusOK, OpenAPI())
+	})
+	return mux
+}
+
+type standardHandler func(std aqi.Standard, w http.ResponseWriter, r *http.Request)
+
+// standardRoutes maps the action after /standards/{std} to its method and
+// handler
+var standardRoutes = map[string]struct {
+	method  string
+	handler standardHandler
+}{
+	"":            {"GET", getStandard},
+	"breakpoints": {"GET", getBreakPoints},
+	"category":    {"GET", getCategory},
+	"inverse":     {"GET", getInverse},
+	"iaqi":        {"POST", postIAQI},
+	"aqi":         {"POST", postAQI},
+}
+
+func allowMethod(w http.ResponseWriter, r *http.Request, method string) bool {
+	if r.Method != method {
+		w.Header().Set("Allow", method)
+		writeError(w, http.StatusMethodNotAllowed, errors.New("Method "+r.Method+" not allowed"))
+		return false
+	}
+	return true
+}
+
+func routeStandard(w http.ResponseWriter, r *http.Request) {
+	parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/standards/"), "/", 2)
+	action := ""
+	if len(parts) == 2 {
+		action = parts[1]
+	}
+	route, ok := standardRoutes[action]
+	if !ok {
+		writeError(w, http.StatusNotFound, errors.New("Not found"))
+		return
+	}
+	std, err := aqi.GetStandard(parts[0])
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	if !allowMethod(w, r, route.method) {
+		return
+	}
+	route.handler(std, w, r)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{err.Error()})
+}
+
+func newCategoryResponse(category aqi.Category) *categoryResponse {
+	return &categoryResponse{
+		Level:       category.Level,
+		Name:        category.Name,
+		From:        category.From,
+		To:          category.To,
+		ColorName:   category.ColorName,
+		Color:       category.Color.RGBToHex(),
+		Description: category.Description,
+		Advice:      category.Advice,
+	}
+}
+
+// categoryOf returns nil when the standard has no categories
+func categoryOf(std aqi.Standard, index int) *categoryResponse {
+	category, err := aqi.GetCategory(std, index)
+	if err != nil {
+		return nil
+	}
+	return newCategoryResponse(category)
+}
+
+func newStandardResponse(std aqi.Standard) standardResponse {
+	result := standardResponse{Name: std.Name(), Pollutants: std.Pollutants()}
+	if categorizer, ok := std.(aqi.Categorizer); ok {
+		for _, category := range categorizer.Categories() {
+			result.Categories = append(result.Categories, *newCategoryResponse(category))
+		}
+	}
+	return result
+}
+
+func listStandards(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, "GET") {
+		return
+	}
+	result := make([]standardResponse, 0)
+	for _, name := range aqi.StandardNames() {
+		std, _ := aqi.GetStandard(name)
+		result = append(result, newStandardResponse(std))
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func getStandard(std aqi.Standard, w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, newStandardResponse(std))
+}
+
+func getBreakPoints(std aqi.Standard, w http.ResponseWriter, r *http.Request) {
+	breakPointer, ok := std.(aqi.BreakPointer)
+	if !ok {
+		writeError(w, http.StatusNotFound, errors.New("Standard "+std.Name()+" has no break points"))
+		return
+	}
+	pollutants := std.Pollutants()
+	if pollutant := r.URL.Query().Get("pollutant"); pollutant != "" {
+		pollutants = []string{pollutant}
+	}
+	result := make(map[string][]bandResponse)
+	for _, pollutant := range pollutants {
+		bands, err := breakPointer.BreakPoints(pollutant)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, errors.New(pollutant+": "+err.Error()))
+			return
+		}
+		table := make([]bandResponse, 0, len(bands))
+		for _, band := range bands {
+			table = append(table, bandResponse(band))
+		}
+		result[pollutant] = table
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func queryInt(r *http.Request, name string) (int, error) {
+	v, err := strconv.Atoi(r.URL.Query().Get(name))
+	if err != nil {
+		return 0, errors.New("Invalid " + name + " " + strconv.Quote(r.URL.Query().Get(name)))
+	}
+	return v, nil
+}
+
+func getCategory(std aqi.Standard, w http.ResponseWriter, r *http.Request) {
+	index, err := queryInt(r, "aqi")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	category, err := aqi.GetCategory(std, index)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newCategoryResponse(category))
+}
+
+func getInverse(std aqi.Standard, w http.ResponseWriter, r *http.Request) {
+	pollutant := r.URL.Query().Get("pollutant")
+	iaqi, err := queryInt(r, "iaqi")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	concentration, err := aqi.InverseIAQI(std, pollutant, iaqi)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, inverseResponse{std.Name(), pollutant, iaqi, concentration})
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return errors.New("Invalid JSON body: " + err.Error())
+	}
+	return nil
+}
+
+// validConcentration rejects negative and non-finite values
+func validConcentration(pollutant string, v float64) error {
+	if math.IsNaN(v) || math.IsInf(v, 0) || v < 0 {
+		return errors.New(pollutant + ": invalid concentration " + strconv.FormatFloat(v, 'g', -1, 64))
+	}
+	return nil
+}
+
+func supported(std aqi.Standard, pollutant string) error {
+	for _, v := range std.Pollutants() {
+		if v == pollutant {
+			return nil
+		}
+	}
+	return errors.New("Standard " + std.Name() + " does not support " + strconv.Quote(pollutant))
+}
+
+func postIAQI(std aqi.Standard, w http.ResponseWriter, r *http.Request) {
+	var req iaqiRequest
+	if err := decodeBody(w, r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if req.Concentration == nil {
+		writeError(w, http.StatusBadRequest, errors.New("Missing concentration"))
+		return
+	}
+	if err := supported(std, req.Pollutant); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := validConcentration(req.Pollutant, *req.Concentration); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	iaqi, err := std.IAQI(req.Pollutant, *req.Concentration)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, iaqiResponse{std.Name(), req.Pollutant, *req.Concentration, iaqi, categoryOf(std, iaqi)})
+}
+
+func postAQI(std aqi.Standard, w http.ResponseWriter, r *http.Request) {
+	concentrations := make(map[string]float64)
+	if err := decodeBody(w, r, &concentrations); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if len(concentrations) == 0 {
+		writeError(w, http.StatusBadRequest, errors.New("No concentration"))
+		return
+	}
+	for pollutant, v := range concentrations {
+		if err := supported(std, pollutant); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		if err := validConcentration(pollutant, v); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+	result, err := aqi.Calculate(std, concentrations)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, aqiResponse{result, categoryOf(std, result.AQI)})
+}
@@ -0,0 +1,136 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func request(t *testing.T, method, target, body string) (*httptest.ResponseRecorder, map[string]interface{}) {
+	var r *http.Request
+	if body == "" {
+		r = httptest.NewRequest(method, target, nil)
+	} else {
+		r = httptest.NewRequest(method, target, strings.NewReader(body))
+	}
+	w := httptest.NewRecorder()
+	NewHandler().ServeHTTP(w, r)
+	var v map[string]interface{}
+	if strings.HasPrefix(strings.TrimSpace(w.Body.String()), "{") {
+		if err := json.Unmarshal(w.Body.Bytes(), &v); err != nil {
+			t.Fatalf("%s %s: %s", method, target, err)
+		}
+	}
+	return w, v
+}
+
+func TestListStandards(t *testing.T) {
+	w, _ := request(t, "GET", "/standards", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status %d", w.Code)
+	}
+	var v []standardResponse
+	json.Unmarshal(w.Body.Bytes(), &v)
+	if len(v) < 2 {
+		t.Errorf("unexpected body %s", w.Body.String())
+	}
+
+	w, body := request(t, "GET", "/standards/mep", "")
+	if w.Code != http.StatusOK || body["name"] != "mep" || len(body["categories"].([]interface{})) != 6 {
+		t.Errorf("unexpected body %s", w.Body.String())
+	}
+	if w, _ = request(t, "GET", "/standards/foo", ""); w.Code != http.StatusNotFound {
+		t.Errorf("fake foo standard status %d", w.Code)
+	}
+}
+
+func TestPostIAQI(t *testing.T) {
+	w, body := request(t, "POST", "/standards/mep/iaqi", `{"pollutant": "pm25_24h", "concentration": 64}`)
+	if w.Code != http.StatusOK || body["iaqi"] != 87.0 {
+		t.Errorf("unexpected body %s", w.Body.String())
+	}
+	if category := body["category"].(map[string]interface{}); category["color"] != "#FFFF00" {
+		t.Errorf("unexpected category %v", category)
+	}
+
+	seeds := []string{
+		`{"pollutant": "so2_24h", "concentration": 10}`,
+		`{"pollutant": "pm25_24h", "concentration": -1}`,
+		`{"pollutant": "pm25_24h"}`,
+		`{"pollutant": "pm25_24h", "concentration": 1, "foo": 1}`,
+		`{`,
+	}
+	for _, seed := range seeds {
+		if w, body = request(t, "POST", "/standards/epa/iaqi", seed); w.Code != http.StatusBadRequest || body["error"] == "" {
+			t.Errorf("%s should be bad request, but %d %s", seed, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestPostAQI(t *testing.T) {
+	w, body := request(t, "POST", "/standards/epa/aqi", `{"co_8h": 8.4, "o3_8h": 0.08742, "pm25_24h": 40.9}`)
+	if w.Code != http.StatusOK || body["aqi"] != 129.0 {
+		t.Fatalf("unexpected body %s", w.Body.String())
+	}
+	if v := body["responsible_pollutants"].([]interface{}); len(v) != 1 || v[0] != "o3_8h" {
+		t.Errorf("unexpected responsible pollutants %v", v)
+	}
+	if iaqis := body["iaqis"].(map[string]interface{}); iaqis["pm25_24h"] != 114.0 {
+		t.Errorf("unexpected iaqis %v", iaqis)
+	}
+
+	for _, seed := range []string{`{}`, `{"so2_24h": 10}`, `{"pm25_24h": -3}`, `[1]`} {
+		if w, _ = request(t, "POST", "/standards/epa/aqi", seed); w.Code != http.StatusBadRequest {
+			t.Errorf("%s should be bad request, but %d", seed, w.Code)
+		}
+	}
+	if w, _ = request(t, "GET", "/standards/epa/aqi", ""); w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET should not be allowed, but %d", w.Code)
+	}
+}
+
+func TestGetLookups(t *testing.T) {
+	w, body := request(t, "GET", "/standards/epa/category?aqi=160", "")
+	if w.Code != http.StatusOK || body["name"] != "Unhealthy" {
+		t.Errorf("unexpected body %s", w.Body.String())
+	}
+	if w, _ = request(t, "GET", "/standards/epa/category?aqi=abc", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("invalid aqi status %d", w.Code)
+	}
+
+	w, body = request(t, "GET", "/standards/mep/inverse?pollutant=pm25_24h&iaqi=50", "")
+	if w.Code != http.StatusOK || body["concentration"] != 35.0 {
+		t.Errorf("unexpected body %s", w.Body.String())
+	}
+	if w, _ = request(t, "GET", "/standards/mep/inverse?pollutant=foo&iaqi=50", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("fake foo pollutant status %d", w.Code)
+	}
+
+	w, body = request(t, "GET", "/standards/mep/breakpoints?pollutant=pm25_24h", "")
+	if w.Code != http.StatusOK || len(body["pm25_24h"].([]interface{})) != 7 {
+		t.Errorf("unexpected body %s", w.Body.String())
+	}
+	if w, _ = request(t, "GET", "/standards/mep/breakpoints?pollutant=foo", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("fake foo pollutant status %d", w.Code)
+	}
+}
+
+func TestOpenAPI(t *testing.T) {
+	w, body := request(t, "GET", "/openapi.json", "")
+	if w.Code != http.StatusOK || body["openapi"] != "3.0.3" {
+		t.Fatalf("unexpected body %s", w.Body.String())
+	}
+	paths := body["paths"].(map[string]interface{})
+	for _, path := range []string{"/standards", "/standards/{std}/iaqi", "/standards/{std}/aqi", "/standards/{std}/inverse"} {
+		if _, ok := paths[path]; !ok {
+			t.Errorf("%s should be documented", path)
+		}
+	}
+	schemas := body["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	properties := schemas["Concentrations"].(map[string]interface{})["properties"].(map[string]interface{})
+	if _, ok := properties["pm25_24h"]; !ok {
+		t.Error("pm25_24h should be a concentration property")
+	}
+}
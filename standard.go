@@ -0,0 +1,383 @@
+package aqi
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// MaxIndex is the top of the index scale of most standards
+const MaxIndex = 500
+
+// Standard is an air quality index standard such as EPA or MEP
+type Standard interface {
+	// Name returns the short name of the standard, e.g. "epa"
+	Name() string
+	// Pollutants returns the pollutant tags the standard can calculate
+	Pollutants() []string
+	// IAQI returns the individual air quality index of a pollutant
+	IAQI(pollutant string, concentration float64) (int, error)
+	// AQI aggregates individual indexes into the composite index
+	AQI(iaqis map[string]int) int
+}
+
+// Band maps a concentration range of a pollutant to an index range
+type Band struct {
+	IAQIFrom float64 `json:"iaqi_from"`
+	IAQITo   float64 `json:"iaqi_to"`
+	From     float64 `json:"from"`
+	To       float64 `json:"to"`
+}
+
+// interpolate returns the index of a concentration in the band
+func (band Band) interpolate(concentration float64) (float64, bool, error) {
+	if band.To == band.From {
+		return -3, false, errors.New("Divided by 0???")
+	}
+	return (band.IAQITo-band.IAQIFrom)/(band.To-band.From)*(concentration-band.From) + band.IAQIFrom, true, nil
+}
+
+// lookupBand returns the band of a concentration and the concentration to
+// interpolate. Bands are half-open intervals (To of the previous band, To], a
+// concentration in the gap below the From of its band, e.g. MEP pm25_24h 35.5
+// between 0-35 and 36-75, is indexed as the From. ok is false below the first
+// band or above the last one
+func lookupBand(bands []Band, concentration float64) (band Band, v float64, ok bool) {
+	for i, band := range bands {
+		if concentration > band.To {
+			continue
+		}
+		if concentration < band.From {
+			if i == 0 {
+				return band, concentration, false
+			}
+			return band, band.From, true
+		}
+		return band, concentration, true
+	}
+	return Band{}, concentration, false
+}
+
+// BreakPointer is implemented by standards using break point tables, bands
+// are ordered and undefined bands are omitted
+type BreakPointer interface {
+	BreakPoints(pollutant string) ([]Band, error)
+}
+
+// breakPoint is a break point of the built-in standard tables
+type breakPoint interface {
+	EPABreakPoint | MEPBreakPoint | CAIBreakPoint | PSIBreakPoint | MEXBreakPoint
+}
+
+// unreportedBreakPoint marks the rows of a break point table a pollutant is
+// not reported in, e.g. EPA 1-hour O3 below 101 and PSI NO2 below 201.
+// Tables convert it to their own break point type
+var unreportedBreakPoint = EPABreakPoint{-1, -1}
+
+// breakPointBands pairs the concentration break points of a pollutant with
+// the index break points, unreportedBreakPoint rows are omitted
+func breakPointBands[P breakPoint](concentrations map[string][]P, iaqis []P, pollutant string) ([]Band, error) {
+	points, ok := concentrations[pollutant]
+	if !ok {
+		return nil, errors.New("Invalid pollutant metric")
+	}
+	result := make([]Band, 0, len(points))
+	for i, p := range points {
+		point, iaqi := EPABreakPoint(p), EPABreakPoint(iaqis[i])
+		if point == unreportedBreakPoint {
+			continue
+		}
+		result = append(result, Band{iaqi.From, iaqi.To, point.From, point.To})
+	}
+	return result, nil
+}
+
+// Result is the calculation result of concentrations keyed by pollutant
+type Result struct {
+	Standard              string             `json:"standard"`
+	Concentrations        map[string]float64 `json:"concentrations"`
+	IAQIs                 map[string]int     `json:"iaqis"`
+	AQI                   int                `json:"aqi"`
+	ResponsiblePollutants []string           `json:"responsible_pollutants"`
+	// Diagnostics are the sanitation diagnostics of invalid concentrations
+	Diagnostics map[string]Diagnostic `json:"diagnostics,omitempty"`
+}
+
+// FloatResult is a Result with the unrounded IAQIs and AQI of the same
+// interpolation
+type FloatResult struct {
+	Result
+	FloatIAQIs map[string]float64 `json:"float_iaqis"`
+	FloatAQI   float64            `json:"float_aqi"`
+}
+
+// FloatIAQIer is implemented by standards calculating the unrounded
+// individual index
+type FloatIAQIer interface {
+	FloatIAQI(pollutant string, concentration float64) (float64, error)
+}
+
+var (
+	standardsMu sync.RWMutex
+	standards   = make(map[string]Standard)
+)
+
+// MaxAggregate returns the max IAQI, which is the composite rule of most
+// standards. It returns -1 when iaqis is empty and BeyondIndexRejected when
+// any IAQI is
+func MaxAggregate(iaqis map[string]int) int {
+	result := -1
+	for _, v := range iaqis {
+		if v == BeyondIndexRejected {
+			return BeyondIndexRejected
+		}
+		if v >= result {
+			result = v
+		}
+	}
+	return result
+}
+
+// RegisterStandard makes a standard available by its name, a standard
+// registered with the same name will be replaced
+func RegisterStandard(std Standard) {
+	standardsMu.Lock()
+	defer standardsMu.Unlock()
+	standards[std.Name()] = std
+}
+
+// GetStandard returns the registered standard by name
+func GetStandard(name string) (Standard, error) {
+	standardsMu.RLock()
+	defer standardsMu.RUnlock()
+	std, ok := standards[name]
+	if !ok {
+		return nil, errors.New("Unknown standard " + name)
+	}
+	return std, nil
+}
+
+// StandardNames returns the sorted names of all registered standards
+func StandardNames() []string {
+	standardsMu.RLock()
+	defer standardsMu.RUnlock()
+	result := make([]string, 0, len(standards))
+	for name := range standards {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// GetAllIAQI calculates the IAQI of every pollutant field of a pollutant
+// struct(pointer) whose json tag is supported by the standard
+func GetAllIAQI(std Standard, pollutant interface{}) map[string]int {
+	result := make(map[string]int)
+	calculable := make(map[string]bool)
+	for _, tag := range std.Pollutants() {
+		calculable[tag] = true
+	}
+	val := reflect.ValueOf(pollutant).Elem()
+	for i := 0; i < val.NumField(); i++ {
+		typeField := val.Type().Field(i)
+		valueField := val.Field(i)
+		if tag := typeField.Tag.Get("json"); calculable[tag] {
+			v, ok := valueField.Interface().(float64)
+			if !ok {
+				v = -1
+			}
+			iaqi, _ := std.IAQI(tag, v)
+			result[tag] = iaqi
+		}
+	}
+	return result
+}
+
+// Calculate calculates every concentration with the standard, it fails on
+// the first pollutant the standard can not calculate. Concentrations of the
+// result are the indexed ones, missing concentrations are left out
+func Calculate(std Standard, concentrations map[string]float64) (Result, error) {
+	result := Result{
+		Standard:       std.Name(),
+		Concentrations: make(map[string]float64),
+		IAQIs:          make(map[string]int),
+	}
+	sanitizer, _ := std.(Sanitizer)
+	uniter, _ := std.(Uniter)
+	for _, pollutant := range sortedKeys(concentrations) {
+		if sanitizer != nil {
+			unit := ""
+			if uniter != nil {
+				unit, _ = uniter.Unit(pollutant)
+			}
+			_, diagnostic, err := sanitizer.SanitationPolicy().Sanitize(concentrations[pollutant], unit)
+			if diagnostic.Issue != IssueNone {
+				if result.Diagnostics == nil {
+					result.Diagnostics = make(map[string]Diagnostic)
+				}
+				result.Diagnostics[pollutant] = diagnostic
+			}
+			// missing concentrations are left out
+			if err == ErrMissingConcentration {
+				continue
+			}
+		}
+		iaqi, err := std.IAQI(pollutant, concentrations[pollutant])
+		if err != nil {
+			return result, errors.New(pollutant + ": " + err.Error())
+		}
+		result.Concentrations[pollutant] = concentrations[pollutant]
+		result.IAQIs[pollutant] = iaqi
+	}
+	result.AQI = std.AQI(result.IAQIs)
+	result.ResponsiblePollutants = ResponsiblePollutants(std, result.IAQIs)
+	return result, nil
+}
+
+// CalculateFloat calculates concentrations like Calculate along with the
+// unrounded IAQIs. The unrounded AQI is the max unrounded IAQI plus what the
+// composite rule of the standard adds to the max IAQI, e.g. the CAI addition
+func CalculateFloat(std Standard, concentrations map[string]float64) (FloatResult, error) {
+	floater, ok := std.(FloatIAQIer)
+	if !ok {
+		return FloatResult{}, errors.New("Standard " + std.Name() + " has no float index")
+	}
+	result, err := Calculate(std, concentrations)
+	if err != nil {
+		return FloatResult{Result: result}, err
+	}
+	floatResult := FloatResult{Result: result, FloatIAQIs: make(map[string]float64)}
+	max := math.Inf(-1)
+	for pollutant, v := range concentrations {
+		if _, ok := result.IAQIs[pollutant]; !ok {
+			continue
+		}
+		iaqi, err := floater.FloatIAQI(pollutant, v)
+		if err != nil {
+			return floatResult, errors.New(pollutant + ": " + err.Error())
+		}
+		floatResult.FloatIAQIs[pollutant] = iaqi
+		max = math.Max(max, iaqi)
+	}
+	floatResult.FloatAQI = float64(result.AQI)
+	if len(floatResult.FloatIAQIs) > 0 {
+		floatResult.FloatAQI = max + float64(result.AQI-MaxAggregate(result.IAQIs))
+	}
+	return floatResult, nil
+}
+
+// ResponsiblePollutants returns the pollutants responsible for the composite
+// index, the ones with the max IAQI unless the standard has its own rule
+func ResponsiblePollutants(std Standard, iaqis map[string]int) []string {
+	if r, ok := std.(interface {
+		ResponsiblePollutants(iaqis map[string]int) []string
+	}); ok {
+		return r.ResponsiblePollutants(iaqis)
+	}
+	return maxPollutants(iaqis)
+}
+
+// InverseIAQI returns the concentration of a pollutant whose unrounded index
+// equals to iaqi. Where it falls among the concentrations rounding to iaqi
+// depends on the rounding of the standard, it's the upper bound under the MEP
+// round up
+func InverseIAQI(std Standard, pollutant string, iaqi int) (float64, error) {
+	breakPointer, ok := std.(BreakPointer)
+	if !ok {
+		return 0, errors.New("Standard " + std.Name() + " has no break points")
+	}
+	bands, err := breakPointer.BreakPoints(pollutant)
+	if err != nil {
+		return 0, err
+	}
+	v := float64(iaqi)
+	for _, band := range bands {
+		if v >= band.IAQIFrom && v <= band.IAQITo {
+			if band.IAQITo == band.IAQIFrom {
+				return band.From, nil
+			}
+			return (v-band.IAQIFrom)*(band.To-band.From)/(band.IAQITo-band.IAQIFrom) + band.From, nil
+		}
+	}
+	return 0, errors.New("Index value out of range")
+}
+
+// maxPollutants returns the pollutants whose IAQI equal to the max one
+func maxPollutants(iaqis map[string]int) []string {
+	result := make([]string, 0)
+	max := MaxAggregate(iaqis)
+	if max < 0 && max != BeyondIndexRejected {
+		return result
+	}
+	for k, v := range iaqis {
+		if v == max {
+			result = append(result, k)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	result := make([]string, 0, len(m))
+	for k := range m {
+		result = append(result, k)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// truncateRules reads the `truncate` struct tag of every calculable field of
+// a pollutant struct(pointer), fields without the tag truncate to integer
+func truncateRules(pollutant interface{}, calculable func(string) bool) map[string]int {
+	result := make(map[string]int)
+	val := reflect.ValueOf(pollutant).Elem()
+	for i := 0; i < val.NumField(); i++ {
+		typeField := val.Type().Field(i)
+		if tag := typeField.Tag.Get("json"); calculable(tag) {
+			t, err := strconv.ParseInt(typeField.Tag.Get("truncate"), 10, 0)
+			if err != nil {
+				result[tag] = 0
+			} else {
+				result[tag] = int(t)
+			}
+		}
+	}
+	return result
+}
+
+// structConcentrations returns the float64 fields of a pollutant
+// struct(pointer) keyed by json tag
+func structConcentrations(pollutant interface{}) map[string]float64 {
+	result := make(map[string]float64)
+	val := reflect.ValueOf(pollutant).Elem()
+	for i := 0; i < val.NumField(); i++ {
+		typeField := val.Type().Field(i)
+		if v, ok := val.Field(i).Interface().(float64); ok {
+			if tag := typeField.Tag.Get("json"); tag != "" {
+				result[tag] = v
+			}
+		}
+	}
+	return result
+}
+
+// setStructConcentrations sets the float64 fields of a pollutant
+// struct(pointer) by json tag, tags std doesn't support fail with a
+// *TagError
+func setStructConcentrations(std Standard, pollutant interface{}, concentrations map[string]float64) error {
+	if err := checkTags(std, sortedKeys(concentrations)); err != nil {
+		return err
+	}
+	val := reflect.ValueOf(pollutant).Elem()
+	for i := 0; i < val.NumField(); i++ {
+		v, ok := concentrations[val.Type().Field(i).Tag.Get("json")]
+		if ok && val.Field(i).Kind() == reflect.Float64 {
+			val.Field(i).SetFloat(v)
+		}
+	}
+	return nil
+}
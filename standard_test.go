@@ -0,0 +1,263 @@
+package aqi
+
+import (
+	"math"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestGetStandard(t *testing.T) {
+	for _, name := range []string{"epa", "mep", "psi", "cai"} {
+		std, err := GetStandard(name)
+		if err != nil {
+			t.Errorf("%s should be registered", name)
+			continue
+		}
+		if std.Name() != name {
+			t.Errorf("err %s, want %s", std.Name(), name)
+		}
+	}
+	if _, err := GetStandard("foo"); err == nil {
+		t.Error("fake foo standard should raise exception")
+	}
+}
+
+func TestRegisterStandardConcurrently(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			name := "test-concurrent-" + strconv.Itoa(i)
+			RegisterStandard(NewPercentStandard(name, map[string]float64{"nh3_24h": 100}, nil))
+			defer func() {
+				standardsMu.Lock()
+				delete(standards, name)
+				standardsMu.Unlock()
+			}()
+			if _, err := GetStandard(name); err != nil {
+				t.Errorf("%s should be registered, but %v", name, err)
+			}
+			StandardNames()
+			RegisteredPollutants()
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestStandardPollutants(t *testing.T) {
+	if v := EpaStandard.Pollutants(); len(v) != len(validEPAPollutants) {
+		t.Errorf("epa should have %d pollutants, but %v", len(validEPAPollutants), v)
+	}
+	if v := MepStandard.Pollutants(); len(v) != len(validMEPPollutants) {
+		t.Errorf("mep should have %d pollutants, but %v", len(validMEPPollutants), v)
+	}
+}
+
+func TestMaxAggregate(t *testing.T) {
+	if v := MaxAggregate(map[string]int{}); v != -1 {
+		t.Errorf("empty should return -1, but %d", v)
+	}
+	if v := MaxAggregate(map[string]int{"pm25_24h": 87, "o3_8h": 104}); v != 104 {
+		t.Errorf("err %d, want 104", v)
+	}
+}
+
+func TestCalculate(t *testing.T) {
+	result, err := Calculate(EpaStandard, map[string]float64{
+		"co_8h":    8.4,
+		"o3_8h":    0.08742,
+		"pm25_24h": 40.9,
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	if result.Standard != "epa" || result.AQI != 129 || result.IAQIs["pm25_24h"] != 114 {
+		t.Errorf("err %#v", result)
+	}
+	if len(result.ResponsiblePollutants) != 1 || result.ResponsiblePollutants[0] != "o3_8h" {
+		t.Errorf("should be o3_8h, but %v", result.ResponsiblePollutants)
+	}
+
+	// mep has no primary pollutant when aqi is not above 50
+	result, _ = Calculate(MepStandard, map[string]float64{"pm25_24h": 20})
+	if len(result.ResponsiblePollutants) != 0 {
+		t.Errorf("should have no primary pollutant, but %v", result.ResponsiblePollutants)
+	}
+
+	if _, err = Calculate(EpaStandard, map[string]float64{"so2_24h": 10}); err == nil {
+		t.Error("so2_24h should raise exception with epa")
+	}
+}
+
+func TestCalculateFloat(t *testing.T) {
+	result, err := CalculateFloat(MepStandard, map[string]float64{"pm25_24h": 82, "pm10_24h": 113})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// (150-101)/(115-76)×(82-76)+101 and (100-51)/(150-51)×(113-51)+51
+	if math.Abs(result.FloatIAQIs["pm25_24h"]-108.538) > 1e-3 || math.Abs(result.FloatIAQIs["pm10_24h"]-81.687) > 1e-3 {
+		t.Errorf("float IAQIs should be pm25_24h 108.538 pm10_24h 81.687, but %v", result.FloatIAQIs)
+	}
+	if result.FloatAQI != result.FloatIAQIs["pm25_24h"] || result.AQI != 109 {
+		t.Errorf("float AQI should be 108.538 along with 109, but %v %d", result.FloatAQI, result.AQI)
+	}
+
+	// the CAI addition of two bad pollutants
+	result, err = CalculateFloat(CaiStandard, map[string]float64{"pm25_24h": 50, "pm10_24h": 120})
+	if err != nil {
+		t.Fatal(err)
+	}
+	max := math.Max(result.FloatIAQIs["pm25_24h"], result.FloatIAQIs["pm10_24h"])
+	if result.AQI != MaxAggregate(result.IAQIs)+CaiTwoBadAddition || result.FloatAQI != max+CaiTwoBadAddition {
+		t.Errorf("float AQI should add %d to %v, but %v", CaiTwoBadAddition, max, result.FloatAQI)
+	}
+
+	for _, name := range StandardNames() {
+		std, _ := GetStandard(name)
+		if _, ok := std.(FloatIAQIer); !ok {
+			t.Errorf("%s should calculate float IAQI", name)
+		}
+	}
+	if _, err = CalculateFloat(MepStandard, map[string]float64{"foo": 1}); err == nil {
+		t.Error("fake foo pollutant should fail with error")
+	}
+}
+
+func TestBreakPoints(t *testing.T) {
+	bands, err := EpaStandard.(BreakPointer).BreakPoints("o3_1h")
+	if err != nil {
+		t.Error(err)
+	}
+	if len(bands) != 5 || bands[0].IAQIFrom != 101 {
+		t.Errorf("undefined o3_1h bands should be omitted, %v", bands)
+	}
+	if bands, _ = PsiStandard.(BreakPointer).BreakPoints("no2_1h"); len(bands) != 3 || bands[0].IAQIFrom != 201 {
+		t.Errorf("unreported no2_1h bands should be omitted, %v", bands)
+	}
+	// only the marker is unreported, a zero row is a band
+	bands, _ = breakPointBands(map[string][]PSIBreakPoint{"foo": {PSIBreakPoint(unreportedBreakPoint), {0, 0}, {1, 10}}},
+		[]PSIBreakPoint{{0, 50}, {51, 100}, {101, 200}}, "foo")
+	if len(bands) != 2 || bands[0].IAQIFrom != 51 {
+		t.Errorf("only unreported bands should be omitted, %v", bands)
+	}
+	if _, err = MepStandard.(BreakPointer).BreakPoints("foo"); err == nil {
+		t.Error("fake foo pollutant should raise exception")
+	}
+	bands, _ = NswStandard.BreakPoints("pm10_24h")
+	if last := bands[len(bands)-1]; last.To != 250 {
+		t.Errorf("open ended category should stop at 500, %v", last)
+	}
+}
+
+func TestInverseIAQI(t *testing.T) {
+	type Seed struct {
+		Standard      Standard
+		Pollutant     string
+		IAQI          int
+		Concentration float64
+	}
+	seeds := []Seed{
+		Seed{MepStandard, "pm25_24h", 50, 35}, Seed{MepStandard, "pm25_24h", 51, 36},
+		Seed{MepStandard, "pm25_24h", 87, 64.653}, Seed{EpaStandard, "pm25_24h", 100, 35.4},
+		Seed{NswStandard, "pm25_24h", 120, 30},
+	}
+	for _, seed := range seeds {
+		v, err := InverseIAQI(seed.Standard, seed.Pollutant, seed.IAQI)
+		if err != nil {
+			t.Error(err)
+		}
+		if Round(v, 3) != seed.Concentration {
+			t.Errorf("%s %s %d should be %f, but %f", seed.Standard.Name(), seed.Pollutant, seed.IAQI, seed.Concentration, v)
+		}
+		if iaqi, _ := seed.Standard.IAQI(seed.Pollutant, v); iaqi != seed.IAQI {
+			t.Errorf("%s %s %f should round trip to %d, but %d", seed.Standard.Name(), seed.Pollutant, v, seed.IAQI, iaqi)
+		}
+	}
+	if _, err := InverseIAQI(MepStandard, "pm25_24h", 600); err == nil {
+		t.Error("600 should be out of range")
+	}
+}
+
+func TestLookupBand(t *testing.T) {
+	type Seed struct {
+		Tag           string
+		Concentration float64
+		IAQI          int
+	}
+	// concentrations in the gaps belong to the upper band
+	mepSeeds := []Seed{{"pm25_24h", 35, 50}, {"pm25_24h", 35.5, 51}, {"pm25_24h", 36, 51}, {"co_24h", 2.5, 51}, {"so2_1h", 650.2, 151}}
+	for _, seed := range mepSeeds {
+		if v, err := GetMepIAQI(seed.Tag, seed.Concentration); err != nil || v != seed.IAQI {
+			t.Errorf("mep %s %v should be %d, but %d %v", seed.Tag, seed.Concentration, seed.IAQI, v, err)
+		}
+	}
+	epaSeeds := []Seed{{"pm25_24h", 350.45, 400}, {"pm25_24h", 350.5, 401}, {"pm25_24h", 12.05, 50}}
+	for _, seed := range epaSeeds {
+		if v, err := GetEpaIAQI(seed.Tag, seed.Concentration); err != nil || v != seed.IAQI {
+			t.Errorf("epa %s %v should be %d, but %d %v", seed.Tag, seed.Concentration, seed.IAQI, v, err)
+		}
+	}
+	if v, err := GetEpaIAQI("o3_1h", 0.1); v != -2 || err == nil {
+		t.Errorf("epa o3_1h below the lowest band should fail with -2, but %d %v", v, err)
+	}
+
+	bands := []Band{{0, 50, 0, 35}, {51, 100, 36, 75}}
+	if _, _, ok := lookupBand(bands, 75.5); ok {
+		t.Error("concentration above the last band should not be found")
+	}
+	if band, v, ok := lookupBand(bands, 35.5); !ok || band.From != 36 || v != 36 {
+		t.Errorf("35.5 should be indexed as 36 of 36-75, but %v %v %v", band, v, ok)
+	}
+}
+
+// TestBreakPointSweep sweeps every table at fine resolution, every
+// concentration within the table must be indexed without error, within its
+// band and never below the index of a lower concentration
+func TestBreakPointSweep(t *testing.T) {
+	for _, name := range StandardNames() {
+		std, _ := GetStandard(name)
+		if _, ok := std.(*PercentStandard); ok {
+			continue
+		}
+		breakPointer := std.(BreakPointer)
+		floater := std.(FloatIAQIer)
+		for _, pollutant := range std.Pollutants() {
+			bands, err := breakPointer.BreakPoints(pollutant)
+			if err != nil || len(bands) == 0 {
+				t.Errorf("%s %s should have bands, but %v", name, pollutant, err)
+				continue
+			}
+			lowest, top := bands[0].From, bands[len(bands)-1].To
+			step := top / 20000
+			last := math.Inf(-1)
+			failed := 0
+			for i := 0; i <= 20000 && failed < 3; i++ {
+				c := step * float64(i)
+				iaqi, err := floater.FloatIAQI(pollutant, c)
+				if c < lowest && c > 0 {
+					continue
+				}
+				if err != nil {
+					t.Errorf("%s %s %v should be indexed, but %v", name, pollutant, c, err)
+					failed++
+					continue
+				}
+				if iaqi < last {
+					t.Errorf("%s %s %v index %v should not be below %v", name, pollutant, c, iaqi, last)
+					failed++
+				}
+				if band, _, ok := lookupBand(bands, c); c > 0 && ok && (iaqi < band.IAQIFrom-1 || iaqi > band.IAQITo) {
+					t.Errorf("%s %s %v index %v should be within %v-%v", name, pollutant, c, iaqi, band.IAQIFrom, band.IAQITo)
+					failed++
+				}
+				if _, err = std.IAQI(pollutant, c); err != nil {
+					t.Errorf("%s %s %v should be indexed, but %v", name, pollutant, c, err)
+					failed++
+				}
+				last = iaqi
+			}
+		}
+	}
+}
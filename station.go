@@ -0,0 +1,294 @@
+package aqi
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultStationDays is the history kept by a station without configuration
+const DefaultStationDays = 7
+
+// stationSnapshotVersion is the version of the snapshot format
+const stationSnapshotVersion = 1
+
+// hourlyRing keeps the latest hourly values of a species, the slot of an
+// hour is hour % len(values)
+type hourlyRing struct {
+	values []float64
+	hours  []int64
+}
+
+func newHourlyRing(capacity int) *hourlyRing {
+	ring := &hourlyRing{values: make([]float64, capacity), hours: make([]int64, capacity)}
+	for i := range ring.hours {
+		ring.hours[i] = math.MinInt64
+	}
+	return ring
+}
+
+func (ring *hourlyRing) slot(hour int64) int {
+	n := int64(len(ring.values))
+	return int((hour%n + n) % n)
+}
+
+func (ring *hourlyRing) set(hour int64, v float64) {
+	i := ring.slot(hour)
+	ring.values[i] = v
+	ring.hours[i] = hour
+}
+
+// get returns NaN for an hour without value
+func (ring *hourlyRing) get(hour int64) float64 {
+	i := ring.slot(hour)
+	if ring.hours[i] != hour {
+		return math.NaN()
+	}
+	return ring.values[i]
+}
+
+// Station keeps the hourly observations of a monitoring station for a
+// bounded number of days and calculates its AQI with any standard on
+// demand. It is safe for concurrent use
+type Station struct {
+	ID string
+
+	mu       sync.RWMutex
+	capacity int
+	// latest is the latest observed hour counted from the Unix epoch
+	latest int64
+	series map[string]*hourlyRing
+}
+
+// NewStation returns a station keeping days of hourly observations,
+// DefaultStationDays when days <= 0
+func NewStation(id string, days int) *Station {
+	if days <= 0 {
+		days = DefaultStationDays
+	}
+	return &Station{
+		ID:       id,
+		capacity: days * 24,
+		latest:   math.MinInt64,
+		series:   make(map[string]*hourlyRing),
+	}
+}
+
+func hourOf(t time.Time) int64 {
+	return t.Truncate(time.Hour).Unix() / 3600
+}
+
+func timeOfHour(hour int64) time.Time {
+	return time.Unix(hour*3600, 0).UTC()
+}
+
+// Add keeps the hourly value of an observation, a later observation of the
+// same hour replaces it. The pollutant is a species or a tag of it, "pm25"
+// or "pm25_1h". Negatives are kept and sanitized by the policy of the
+// standard calculating them. NaN, infinities and observations older than the
+// kept days fail
+func (s *Station) Add(observation Observation) error {
+	if observation.Station != "" && observation.Station != s.ID {
+		return errors.New("Observation of station " + observation.Station)
+	}
+	v := observation.Value
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return errors.New("Invalid concentration")
+	}
+	name := observation.Pollutant
+	if _, err := AveragingHours(name); err == nil {
+		name = species(name)
+	}
+	if name == "" {
+		return errors.New("Invalid pollutant metric")
+	}
+	hour := hourOf(observation.Time)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.latest != math.MinInt64 && hour <= s.latest-int64(s.capacity) {
+		return errors.New("Observation out of window")
+	}
+	ring, ok := s.series[name]
+	if !ok {
+		ring = newHourlyRing(s.capacity)
+		s.series[name] = ring
+	}
+	ring.set(hour, v)
+	if hour > s.latest {
+		s.latest = hour
+	}
+	return nil
+}
+
+// Latest returns the latest observed hour, zero time without observations
+func (s *Station) Latest() time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.latest == math.MinInt64 {
+		return time.Time{}
+	}
+	return timeOfHour(s.latest)
+}
+
+// Hourly returns the values of a species of hours before at, most recent
+// first, NaN for missing hours
+func (s *Station) Hourly(pollutant string, at time.Time, hours int) []float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.hourly(pollutant, hourOf(at), hours)
+}
+
+func (s *Station) hourly(pollutant string, hour int64, hours int) []float64 {
+	ring, ok := s.series[pollutant]
+	if !ok {
+		return nil
+	}
+	values := make([]float64, hours)
+	for i := range values {
+		values[i] = math.NaN()
+		if h := hour - int64(i); h > s.latest-int64(s.capacity) {
+			values[i] = ring.get(h)
+		}
+	}
+	return values
+}
+
+// AQI calculates the rolling averages of the latest hour with a standard
+func (s *Station) AQI(std Standard) (StationResult, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.latest == math.MinInt64 {
+		return StationResult{Station: s.ID}, errors.New("No observation")
+	}
+	result := s.resultAt(std, averagingWindows(std), s.latest)
+	return result, result.Err
+}
+
+// AQIAt calculates the rolling averages of the hour of at with a standard
+func (s *Station) AQIAt(std Standard, at time.Time) (StationResult, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	result := s.resultAt(std, averagingWindows(std), hourOf(at))
+	return result, result.Err
+}
+
+func (s *Station) resultAt(std Standard, windows map[string]int, hour int64) StationResult {
+	result := StationResult{Station: s.ID, Time: timeOfHour(hour)}
+	result.Result, result.Err = calculateHourly(std, windows, 0,
+		func(name string, hours int) []float64 {
+			return s.hourly(name, hour, hours)
+		})
+	return result
+}
+
+// History returns the hourly results of the last days up to the latest
+// hour, oldest first. Hours which can not be calculated have Err set
+func (s *Station) History(std Standard, days int) []StationResult {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.latest == math.MinInt64 || days <= 0 {
+		return nil
+	}
+	hours := days * 24
+	if hours > s.capacity {
+		hours = s.capacity
+	}
+	windows := averagingWindows(std)
+	results := make([]StationResult, 0, hours)
+	for hour := s.latest - int64(hours) + 1; hour <= s.latest; hour++ {
+		results = append(results, s.resultAt(std, windows, hour))
+	}
+	return results
+}
+
+type stationValue struct {
+	Time  time.Time `json:"time"`
+	Value float64   `json:"value"`
+}
+
+type stationSnapshot struct {
+	Version int                       `json:"version"`
+	ID      string                    `json:"id"`
+	Days    int                       `json:"days"`
+	Series  map[string][]stationValue `json:"series"`
+}
+
+// Snapshot writes the observations of the station to w in JSON
+func (s *Station) Snapshot(w io.Writer) error {
+	s.mu.RLock()
+	snapshot := stationSnapshot{
+		Version: stationSnapshotVersion,
+		ID:      s.ID,
+		Days:    s.capacity / 24,
+		Series:  make(map[string][]stationValue),
+	}
+	for name, ring := range s.series {
+		values := make([]stationValue, 0)
+		for i, hour := range ring.hours {
+			if hour != math.MinInt64 && hour > s.latest-int64(s.capacity) {
+				values = append(values, stationValue{timeOfHour(hour), ring.values[i]})
+			}
+		}
+		sort.Slice(values, func(i, j int) bool {
+			return values[i].Time.Before(values[j].Time)
+		})
+		snapshot.Series[name] = values
+	}
+	s.mu.RUnlock()
+	return json.NewEncoder(w).Encode(snapshot)
+}
+
+// RestoreStation reads a station written by Snapshot
+func RestoreStation(r io.Reader) (*Station, error) {
+	var snapshot stationSnapshot
+	if err := json.NewDecoder(r).Decode(&snapshot); err != nil {
+		return nil, err
+	}
+	if snapshot.Version != stationSnapshotVersion {
+		return nil, errors.New("Unsupported snapshot version")
+	}
+	s := NewStation(snapshot.ID, snapshot.Days)
+	for name, values := range snapshot.Series {
+		for _, v := range values {
+			if err := s.Add(Observation{Time: v.Time, Pollutant: name, Value: v.Value}); err != nil {
+				return nil, errors.New(name + ": " + err.Error())
+			}
+		}
+	}
+	return s, nil
+}
+
+// Save writes a snapshot of the station to the file at path, replacing it
+// only once the snapshot is complete
+func (s *Station) Save(path string) error {
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	if err = s.Snapshot(file); err != nil {
+		file.Close()
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
+
+// LoadStation reads a station saved to the file at path
+func LoadStation(path string) (*Station, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return RestoreStation(file)
+}
@@ -0,0 +1,169 @@
+package aqi
+
+import (
+	"bytes"
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestStation(t *testing.T, days, hours int) *Station {
+	station := NewStation("1001", days)
+	start := time.Date(2013, 1, 15, 0, 0, 0, 0, time.UTC)
+	for hour := 0; hour < hours; hour++ {
+		at := start.Add(time.Duration(hour) * time.Hour)
+		for _, observation := range []Observation{
+			{Time: at, Pollutant: "pm25", Value: 82},
+			{Station: "1001", Time: at, Pollutant: "pm10_1h", Value: 113},
+		} {
+			if err := station.Add(observation); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	return station
+}
+
+func TestStationAQI(t *testing.T) {
+	station := newTestStation(t, 1, 24)
+	if latest := station.Latest(); !latest.Equal(time.Date(2013, 1, 15, 23, 0, 0, 0, time.UTC)) {
+		t.Errorf("latest hour should be 23:00, but %v", latest)
+	}
+
+	type Seed struct {
+		Standard Standard
+		AQI      int
+	}
+	seeds := []Seed{
+		{MepStandard, 109},
+		{EpaStandard, 165},
+	}
+	for _, seed := range seeds {
+		result, err := station.AQI(seed.Standard)
+		if err != nil || result.AQI != seed.AQI || result.Concentrations["pm25_24h"] != 82 {
+			t.Errorf("%s AQI should be %d, but %v %v", seed.Standard.Name(), seed.AQI, result.Result, err)
+		}
+	}
+
+	if _, err := NewStation("1002", 1).AQI(MepStandard); err == nil {
+		t.Error("station without observation should fail with error")
+	}
+	// 17 valid hours of 24
+	if _, err := station.AQIAt(MepStandard, time.Date(2013, 1, 15, 16, 0, 0, 0, time.UTC)); err == nil {
+		t.Error("incomplete 24h average should fail with error")
+	}
+}
+
+func TestStationAdd(t *testing.T) {
+	station := NewStation("1001", 1)
+	at := time.Date(2013, 1, 15, 0, 0, 0, 0, time.UTC)
+	seeds := []Observation{
+		{Station: "1002", Time: at, Pollutant: "pm25", Value: 82},
+		{Time: at, Pollutant: "pm25", Value: math.NaN()},
+		{Time: at, Pollutant: "", Value: 82},
+	}
+	for _, seed := range seeds {
+		if err := station.Add(seed); err == nil {
+			t.Errorf("%v should fail with error", seed)
+		}
+	}
+
+	if err := station.Add(Observation{Time: at.Add(24 * time.Hour), Pollutant: "pm25", Value: 82}); err != nil {
+		t.Fatal(err)
+	}
+	if err := station.Add(Observation{Time: at, Pollutant: "pm25", Value: 82}); err == nil {
+		t.Error("observation out of window should fail with error")
+	}
+}
+
+func TestStationSanitation(t *testing.T) {
+	station := newTestStation(t, 1, 24)
+	latest := station.Latest()
+	if err := station.Add(Observation{Time: latest, Pollutant: "no2", Value: -3}); err != nil {
+		t.Fatal(err)
+	}
+	result, err := station.AQI(MepStandard)
+	if err != nil || result.Concentrations["no2_1h"] != 0 || result.Diagnostics["no2_1h"].Action != ActionClamped {
+		t.Errorf("no2 -3 should be clamped to 0, but %v %v", result.Result, err)
+	}
+	if err = station.Add(Observation{Time: latest, Pollutant: "no2", Value: -500}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = station.AQI(MepStandard); err == nil {
+		t.Error("no2 -500 should be rejected")
+	}
+}
+
+func TestStationRing(t *testing.T) {
+	station := newTestStation(t, 1, 48)
+	hourly := station.Hourly("pm25", station.Latest(), 30)
+	for i, v := range hourly {
+		if i < 24 && v != 82 {
+			t.Errorf("hour %d should be kept, but %v", i, v)
+		}
+		if i >= 24 && !math.IsNaN(v) {
+			t.Errorf("hour %d should be overwritten, but %v", i, v)
+		}
+	}
+	if hourly := station.Hourly("o3", station.Latest(), 8); hourly != nil {
+		t.Errorf("unobserved species should be nil, but %v", hourly)
+	}
+}
+
+func TestStationHistory(t *testing.T) {
+	station := newTestStation(t, 2, 30)
+	history := station.History(MepStandard, 1)
+	if len(history) != 24 {
+		t.Fatalf("history of 1 day should be 24 hours, but %d", len(history))
+	}
+	if first := history[0].Time; !first.Equal(time.Date(2013, 1, 15, 6, 0, 0, 0, time.UTC)) {
+		t.Errorf("history should start at 06:00, but %v", first)
+	}
+	for i, result := range history {
+		// 20 valid hours of 24 are required
+		if i < 13 && result.Err == nil {
+			t.Errorf("%v should fail with error", result.Time)
+		}
+		if i >= 13 && (result.Err != nil || result.AQI != 109) {
+			t.Errorf("%v should be AQI 109, but %v %v", result.Time, result.Result, result.Err)
+		}
+	}
+	if history := station.History(MepStandard, 3); len(history) != 48 {
+		t.Errorf("history should be bounded by 2 days, but %d", len(history))
+	}
+}
+
+func TestStationSnapshot(t *testing.T) {
+	station := newTestStation(t, 1, 30)
+	path := filepath.Join(t.TempDir(), "1001.json")
+	if err := station.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	restored, err := LoadStation(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.ID != "1001" || !restored.Latest().Equal(station.Latest()) {
+		t.Errorf("restored station should be 1001 at %v, but %s at %v", station.Latest(), restored.ID, restored.Latest())
+	}
+	expected, _ := station.AQI(MepStandard)
+	result, err := restored.AQI(MepStandard)
+	if err != nil || result.AQI != expected.AQI {
+		t.Errorf("restored AQI should be %d, but %d %v", expected.AQI, result.AQI, err)
+	}
+
+	var buf bytes.Buffer
+	if err = restored.Snapshot(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var saved bytes.Buffer
+	station.Snapshot(&saved)
+	if buf.String() != saved.String() {
+		t.Errorf("snapshot should round trip, but\n%s\n%s", buf.String(), saved.String())
+	}
+
+	if _, err = RestoreStation(bytes.NewBufferString(`{"version": 2}`)); err == nil {
+		t.Error("unsupported version should fail with error")
+	}
+}
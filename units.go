@@ -0,0 +1,105 @@
+package aqi
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// units of concentrations
+const (
+	UnitUgm3 = "µg/m³"
+	UnitMgm3 = "mg/m³"
+	UnitPpb  = "ppb"
+	UnitPpm  = "ppm"
+)
+
+// MolarVolume is the molar volume in liters of an ideal gas at 25 °C and
+// 101.325 kPa, the reference conditions ppb and ppm are converted with
+const MolarVolume = 24.45
+
+// units of the built-in standards by species
+var (
+	ugm3Units = map[string]string{"so2": UnitUgm3, "no2": UnitUgm3, "co": UnitMgm3, "o3": UnitUgm3, "pm10": UnitUgm3, "pm25": UnitUgm3}
+	ppmUnits  = map[string]string{"so2": UnitPpm, "no2": UnitPpm, "co": UnitPpm, "o3": UnitPpm, "pm10": UnitUgm3, "pm25": UnitUgm3}
+	epaUnits  = map[string]string{"so2": UnitPpb, "no2": UnitPpb, "co": UnitPpm, "o3": UnitPpm, "pm10": UnitUgm3, "pm25": UnitUgm3}
+)
+
+// Uniter is implemented by standards declaring the unit of their
+// concentrations
+type Uniter interface {
+	Unit(pollutant string) (string, error)
+}
+
+// NormalizeUnit returns the unit constant of a unit spelling, e.g. "ug/m3"
+// is UnitUgm3
+func NormalizeUnit(unit string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(unit)) {
+	case "µg/m³", "µg/m3", "μg/m³", "μg/m3", "ug/m³", "ug/m3":
+		return UnitUgm3, nil
+	case "mg/m³", "mg/m3":
+		return UnitMgm3, nil
+	case "ppb":
+		return UnitPpb, nil
+	case "ppm":
+		return UnitPpm, nil
+	}
+	return "", errors.New("Unknown unit " + unit)
+}
+
+// ConvertUnit converts a concentration of a species, e.g. "o3" of the tag
+// o3_8h, between units. ppb and ppm of gases are converted at MolarVolume
+func ConvertUnit(species string, v float64, from, to string) (float64, error) {
+	from, err := NormalizeUnit(from)
+	if err != nil {
+		return 0, err
+	}
+	if to, err = NormalizeUnit(to); err != nil {
+		return 0, err
+	}
+	if from == to {
+		return v, nil
+	}
+	// via µg/m³
+	scales := map[string]float64{UnitUgm3: 1, UnitMgm3: 1000}
+	if from == UnitPpb || from == UnitPpm || to == UnitPpb || to == UnitPpm {
+		s, _ := LookupSpecies(species)
+		weight := s.MolecularWeight
+		if weight == 0 {
+			return 0, errors.New("No molecular weight of " + species)
+		}
+		scales[UnitPpb] = weight / MolarVolume
+		scales[UnitPpm] = 1000 * weight / MolarVolume
+	}
+	return v * scales[from] / scales[to], nil
+}
+
+// ParseQuantity parses a concentration with an optional unit suffix, e.g.
+// "70 ppb" or "35.5µg/m³". unit is "" without suffix
+func ParseQuantity(s string) (v float64, unit string, err error) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return !unicode.IsDigit(r) && !strings.ContainsRune(".+-eE", r)
+	})
+	number := s
+	if i >= 0 {
+		number = s[:i]
+		if unit, err = NormalizeUnit(s[i:]); err != nil {
+			return 0, "", err
+		}
+	}
+	if v, err = strconv.ParseFloat(strings.TrimSpace(number), 64); err != nil {
+		return 0, "", errors.New("Invalid concentration " + s)
+	}
+	return v, unit, nil
+}
+
+// unitOf returns the unit of a pollutant tag from units by species
+func unitOf(units map[string]string, pollutant string) (string, error) {
+	unit, ok := units[species(pollutant)]
+	if !ok {
+		return "", errors.New("Invalid pollutant metric")
+	}
+	return unit, nil
+}
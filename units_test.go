@@ -0,0 +1,80 @@
+package aqi
+
+import (
+	"math"
+	"testing"
+)
+
+func TestConvertUnit(t *testing.T) {
+	type Seed struct {
+		Species  string
+		V        float64
+		From, To string
+		Expect   float64
+	}
+	seeds := []Seed{
+		{"o3", 70, "ppb", "ppm", 0.07},
+		{"no2", 100, "ug/m3", "ppb", 53.146},
+		{"so2", 10, "ppb", UnitUgm3, 26.203},
+		{"co", 5, "mg/m3", "ppm", 4.364},
+		{"co", 1.2, "mg/m³", "µg/m³", 1200},
+		{"pm25", 35.4, "μg/m³", UnitUgm3, 35.4},
+	}
+	for _, seed := range seeds {
+		v, err := ConvertUnit(seed.Species, seed.V, seed.From, seed.To)
+		if err != nil || math.Abs(v-seed.Expect) > 1e-3 {
+			t.Errorf("%s %v %s should be %v %s, but %v %v", seed.Species, seed.V, seed.From, seed.Expect, seed.To, v, err)
+		}
+	}
+	if _, err := ConvertUnit("pm25", 35, "ppb", UnitUgm3); err == nil {
+		t.Error("pm25 ppb should fail with error")
+	}
+	if _, err := ConvertUnit("o3", 35, "ppt", UnitUgm3); err == nil {
+		t.Error("unknown unit should fail with error")
+	}
+}
+
+func TestParseQuantity(t *testing.T) {
+	type Seed struct {
+		S    string
+		V    float64
+		Unit string
+	}
+	seeds := []Seed{{"70 ppb", 70, UnitPpb}, {"35.5µg/m³", 35.5, UnitUgm3}, {" 1.2 MG/M3 ", 1.2, UnitMgm3}, {"12", 12, ""}, {"1e2 ppm", 100, UnitPpm}}
+	for _, seed := range seeds {
+		v, unit, err := ParseQuantity(seed.S)
+		if err != nil || v != seed.V || unit != seed.Unit {
+			t.Errorf("%q should be %v %s, but %v %s %v", seed.S, seed.V, seed.Unit, v, unit, err)
+		}
+	}
+	for _, s := range []string{"ppb", "70 furlongs", ""} {
+		if _, _, err := ParseQuantity(s); err == nil {
+			t.Errorf("%q should fail with error", s)
+		}
+	}
+}
+
+func TestStandardUnit(t *testing.T) {
+	for _, name := range StandardNames() {
+		std, _ := GetStandard(name)
+		uniter, ok := std.(Uniter)
+		if !ok {
+			t.Errorf("%s should declare its units", name)
+			continue
+		}
+		for _, pollutant := range std.Pollutants() {
+			if _, err := uniter.Unit(pollutant); err != nil {
+				t.Errorf("%s %s should have a unit, but %v", name, pollutant, err)
+			}
+		}
+	}
+	if unit, _ := (epaStandard{}).Unit("so2_1h"); unit != UnitPpb {
+		t.Errorf("epa so2_1h should be ppb, but %s", unit)
+	}
+	if unit, _ := (mepStandard{}).Unit("co_24h"); unit != UnitMgm3 {
+		t.Errorf("mep co_24h should be mg/m³, but %s", unit)
+	}
+	if _, err := (mepStandard{}).Unit("co_8h"); err == nil {
+		t.Error("mep co_8h should fail with error")
+	}
+}
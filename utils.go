@@ -1,15 +1,13 @@
 package aqi
 
 import (
-	"fmt"
 	"math"
-	"strconv"
+	"sort"
 )
 
+// TruncateFloat truncates v to digit decimal digits, see RoundDecimal
 func TruncateFloat(v float64, digit int) float64 {
-	i := fmt.Sprintf("%"+fmt.Sprintf(".%df", digit), v)
-	f, _ := strconv.ParseFloat(i, digit)
-	return f
+	return RoundDecimal(v, digit, RoundTruncate)
 }
 
 // see: https://github.com/DeyV/gotools
@@ -27,3 +25,21 @@ func Round(x float64, prec int) float64 {
 
 	return rounder / float64(pow)
 }
+
+// Percentile returns the p(0~100) percentile of values with linear
+// interpolation between closest ranks, k = 1 + (n-1)·p/100, values will not
+// be modified. It returns NaN when values is empty or p is out of 0~100
+func Percentile(values []float64, p float64) float64 {
+	if len(values) == 0 || !(p >= 0 && p <= 100) {
+		return math.NaN()
+	}
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+	k := 1 + float64(len(sorted)-1)*p/100
+	s := int(k)
+	if s >= len(sorted) {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[s-1] + (sorted[s]-sorted[s-1])*(k-float64(s))
+}
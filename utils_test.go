@@ -1,6 +1,7 @@
 package aqi
 
 import (
+	"math"
 	"testing"
 )
 
@@ -13,3 +14,38 @@ func TestTruncateFloat(t *testing.T) {
 		}
 	}
 }
+
+func TestTruncateFloatTruncates(t *testing.T) {
+	// TruncateFloat used to round by fmt's %.Nf, 12.05 truncates to 12.0
+	if v := TruncateFloat(12.05, 1); v != 12 {
+		t.Errorf("12.05 should be truncated to 12, but %v", v)
+	}
+	if v := TruncateFloat(0.0759, 3); v != 0.075 {
+		t.Errorf("0.0759 should be truncated to 0.075, but %v", v)
+	}
+	// so pm25_24h 12.05 is 50 as the EPA truncation rule gives, not 51
+	if v, _ := GetEpaIAQI("pm25_24h", 12.05); v != 50 {
+		t.Errorf("pm25_24h 12.05 should be 50, but %d", v)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	values := []float64{35, 20, 15, 40, 50}
+	seeds := map[float64]float64{0: 15, 50: 35, 90: 46, 95: 48, 100: 50}
+	for p, expect := range seeds {
+		if v := Percentile(values, p); math.Abs(v-expect) > 1e-9 {
+			t.Errorf("p%f err %f, expect %f", p, v, expect)
+		}
+	}
+	if values[0] != 35 {
+		t.Error("values should not be modified")
+	}
+	if v := Percentile(nil, 50); !math.IsNaN(v) {
+		t.Errorf("empty values should return NaN, but %f", v)
+	}
+	for _, p := range []float64{-1, 100.5, math.NaN(), math.Inf(1)} {
+		if v := Percentile(values, p); !math.IsNaN(v) {
+			t.Errorf("p%f should return NaN, but %f", p, v)
+		}
+	}
+}
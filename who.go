@@ -0,0 +1,144 @@
+package aqi
+
+import (
+	"errors"
+	"math"
+)
+
+const (
+	// WHO 24-hr guidelines are defined as the 99th percentile of daily values
+	WhoShortTermPercentile = 99
+	WhoGuidelineLevel      = "AQG"
+)
+
+// WhoLevel is an interim target(IT-1..IT-4) or the guideline level(AQG)
+type WhoLevel struct {
+	Name  string
+	Value float64
+}
+
+// WhoGuideline holds the WHO 2021 levels of a pollutant ordered from the
+// loosest interim target to the guideline level. ShortTerm is the 24-hr(o3:
+// daily max 8-hr) level, LongTerm is the annual(o3: peak season) level
+type WhoGuideline struct {
+	Pollutant string
+	ShortTerm []WhoLevel
+	LongTerm  []WhoLevel
+}
+
+// WhoEvaluation reports a pollutant against the WHO 2021 levels, an empty
+// target means the concentration is above IT-1
+type WhoEvaluation struct {
+	Pollutant       string
+	ShortTermTarget string
+	ShortTermRatio  float64 // 99th percentile of daily values / 24-hr AQG
+	ExceedanceDays  int     // days above the 24-hr AQG
+	LongTermTarget  string
+	LongTermRatio   float64 // long-term average / long-term AQG
+	HasLongTerm     bool
+}
+
+var whoGuidelines map[string]WhoGuideline
+
+// initliaze WHO Global Air Quality Guidelines 2021, µg/m³ except co(mg/m³)
+func init() {
+	whoGuidelines = make(map[string]WhoGuideline)
+
+	whoGuidelines["pm25_24h"] = WhoGuideline{
+		Pollutant: "pm25_24h",
+		ShortTerm: []WhoLevel{{"IT-1", 75}, {"IT-2", 50}, {"IT-3", 37.5}, {"IT-4", 25}, {WhoGuidelineLevel, 15}},
+		LongTerm:  []WhoLevel{{"IT-1", 35}, {"IT-2", 25}, {"IT-3", 15}, {"IT-4", 10}, {WhoGuidelineLevel, 5}},
+	}
+	whoGuidelines["pm10_24h"] = WhoGuideline{
+		Pollutant: "pm10_24h",
+		ShortTerm: []WhoLevel{{"IT-1", 150}, {"IT-2", 100}, {"IT-3", 75}, {"IT-4", 50}, {WhoGuidelineLevel, 45}},
+		LongTerm:  []WhoLevel{{"IT-1", 70}, {"IT-2", 50}, {"IT-3", 30}, {"IT-4", 20}, {WhoGuidelineLevel, 15}},
+	}
+	whoGuidelines["o3_8h"] = WhoGuideline{
+		Pollutant: "o3_8h",
+		ShortTerm: []WhoLevel{{"IT-1", 160}, {"IT-2", 120}, {WhoGuidelineLevel, 100}},
+		LongTerm:  []WhoLevel{{"IT-1", 100}, {"IT-2", 70}, {WhoGuidelineLevel, 60}},
+	}
+	whoGuidelines["no2_24h"] = WhoGuideline{
+		Pollutant: "no2_24h",
+		ShortTerm: []WhoLevel{{"IT-1", 120}, {"IT-2", 50}, {WhoGuidelineLevel, 25}},
+		LongTerm:  []WhoLevel{{"IT-1", 40}, {"IT-2", 30}, {"IT-3", 20}, {WhoGuidelineLevel, 10}},
+	}
+	whoGuidelines["so2_24h"] = WhoGuideline{
+		Pollutant: "so2_24h",
+		ShortTerm: []WhoLevel{{"IT-1", 125}, {"IT-2", 50}, {WhoGuidelineLevel, 40}},
+	}
+	whoGuidelines["co_24h"] = WhoGuideline{
+		Pollutant: "co_24h",
+		ShortTerm: []WhoLevel{{"IT-1", 7}, {WhoGuidelineLevel, 4}},
+	}
+}
+
+// GetWhoGuideline returns the WHO 2021 levels of a pollutant
+func GetWhoGuideline(pollutant string) (WhoGuideline, bool) {
+	guideline, ok := whoGuidelines[pollutant]
+	return guideline, ok
+}
+
+// whoTarget returns the strictest level the concentration meets
+func whoTarget(levels []WhoLevel, concentration float64) string {
+	var result string
+	for _, level := range levels {
+		if concentration <= level.Value {
+			result = level.Name
+		}
+	}
+	return result
+}
+
+// EvaluateWho evaluates daily averages(o3: daily max 8-hr) and the long-term
+// average(annual, o3: peak season) of a pollutant, pass NaN as longTerm when
+// it is not available
+func EvaluateWho(pollutant string, daily []float64, longTerm float64) (WhoEvaluation, error) {
+	result := WhoEvaluation{Pollutant: pollutant}
+	guideline, ok := whoGuidelines[pollutant]
+	if !ok {
+		return result, errors.New("Invalid pollutant metric")
+	}
+	if len(daily) == 0 {
+		return result, errors.New("No daily values")
+	}
+	aqg := guideline.ShortTerm[len(guideline.ShortTerm)-1].Value
+	for _, v := range daily {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return result, errors.New("Invalid daily value")
+		}
+		if v > aqg {
+			result.ExceedanceDays++
+		}
+	}
+	percentile := Percentile(daily, WhoShortTermPercentile)
+	result.ShortTermTarget = whoTarget(guideline.ShortTerm, percentile)
+	result.ShortTermRatio = percentile / aqg
+
+	if len(guideline.LongTerm) > 0 && !math.IsNaN(longTerm) {
+		aqg = guideline.LongTerm[len(guideline.LongTerm)-1].Value
+		result.HasLongTerm = true
+		result.LongTermTarget = whoTarget(guideline.LongTerm, longTerm)
+		result.LongTermRatio = longTerm / aqg
+	}
+	return result, nil
+}
+
+// EvaluateWhoAll evaluates every pollutant of daily, long-term averages
+// missing from longTerm are not evaluated
+func EvaluateWhoAll(daily map[string][]float64, longTerm map[string]float64) (map[string]WhoEvaluation, error) {
+	result := make(map[string]WhoEvaluation)
+	for pollutant, values := range daily {
+		v, ok := longTerm[pollutant]
+		if !ok {
+			v = math.NaN()
+		}
+		evaluation, err := EvaluateWho(pollutant, values, v)
+		if err != nil {
+			return result, errors.New(pollutant + ": " + err.Error())
+		}
+		result[pollutant] = evaluation
+	}
+	return result, nil
+}
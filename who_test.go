@@ -0,0 +1,84 @@
+package aqi
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGetWhoGuideline(t *testing.T) {
+	for _, v := range []string{"pm25_24h", "pm10_24h", "o3_8h", "no2_24h", "so2_24h", "co_24h"} {
+		guideline, ok := GetWhoGuideline(v)
+		if !ok {
+			t.Errorf("%s should have guideline", v)
+			continue
+		}
+		if guideline.ShortTerm[len(guideline.ShortTerm)-1].Name != WhoGuidelineLevel {
+			t.Errorf("%s last short term level should be AQG", v)
+		}
+	}
+	if _, ok := GetWhoGuideline("foo"); ok {
+		t.Error("fake foo pollutant should not have guideline")
+	}
+}
+
+func TestEvaluateWho(t *testing.T) {
+	if _, err := EvaluateWho("foo", []float64{1}, 1); err == nil {
+		t.Error("fake foo pollutant should raise exception")
+	}
+	if _, err := EvaluateWho("pm25_24h", []float64{10, math.NaN()}, 1); err == nil {
+		t.Error("NaN daily value should fail with error")
+	}
+	if _, err := EvaluateWho("pm25_24h", nil, 1); err == nil {
+		t.Error("empty daily values should raise exception")
+	}
+
+	daily := make([]float64, 0)
+	for i := 0; i < 100; i++ {
+		daily = append(daily, 10)
+	}
+	// 99th percentile 20 + (40 - 20)·0.01 = 20.2
+	daily[50], daily[60] = 40, 20
+	result, _ := EvaluateWho("pm25_24h", daily, 12)
+	if result.ExceedanceDays != 2 {
+		t.Errorf("exceedance days err %d, want 2", result.ExceedanceDays)
+	}
+	if result.ShortTermTarget != "IT-4" {
+		t.Errorf("short term target err %s, want IT-4", result.ShortTermTarget)
+	}
+	if math.Abs(result.ShortTermRatio-20.2/15) > 1e-9 {
+		t.Errorf("short term ratio err %f", result.ShortTermRatio)
+	}
+	if !result.HasLongTerm || result.LongTermTarget != "IT-3" {
+		t.Errorf("long term target err %s, want IT-3", result.LongTermTarget)
+	}
+	if result.LongTermRatio != 12.0/5 {
+		t.Errorf("long term ratio err %f", result.LongTermRatio)
+	}
+
+	result, _ = EvaluateWho("so2_24h", []float64{200}, 10)
+	if result.ShortTermTarget != "" {
+		t.Errorf("above IT-1 should have no target, but %s", result.ShortTermTarget)
+	}
+	if result.HasLongTerm {
+		t.Error("so2 has no long term guideline")
+	}
+}
+
+func TestEvaluateWhoAll(t *testing.T) {
+	result, err := EvaluateWhoAll(
+		map[string][]float64{"pm10_24h": []float64{30, 60}, "co_24h": []float64{5}},
+		map[string]float64{"pm10_24h": 45},
+	)
+	if err != nil {
+		t.Error(err)
+	}
+	if v := result["pm10_24h"]; v.LongTermTarget != "IT-2" || v.ExceedanceDays != 1 {
+		t.Errorf("pm10_24h err %#v", v)
+	}
+	if v := result["co_24h"]; v.ShortTermTarget != "IT-1" || v.HasLongTerm {
+		t.Errorf("co_24h err %#v", v)
+	}
+	if _, err = EvaluateWhoAll(map[string][]float64{"foo": []float64{1}}, nil); err == nil {
+		t.Error("fake foo pollutant should raise exception")
+	}
+}